@@ -13,12 +13,14 @@ import (
 	csvreader "github.com/erknas/wt-guided-weapons/internal/lib/csv-reader"
 	"github.com/erknas/wt-guided-weapons/internal/logger"
 	"github.com/erknas/wt-guided-weapons/internal/server"
+	categoriesservice "github.com/erknas/wt-guided-weapons/internal/services/categories-service"
 	versionservice "github.com/erknas/wt-guided-weapons/internal/services/version-service"
 	"github.com/erknas/wt-guided-weapons/internal/services/version-service/observer"
 	versionparser "github.com/erknas/wt-guided-weapons/internal/services/version-service/version-parser"
 	weaponsservice "github.com/erknas/wt-guided-weapons/internal/services/weapons-service"
 	weaponmapper "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-mapper"
 	weaponsparser "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-parser"
+	weaponsuggester "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-suggester"
 	weaponsaggregator "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapons-aggregator"
 	"github.com/erknas/wt-guided-weapons/internal/storage/mongodb"
 	urlsloader "github.com/erknas/wt-guided-weapons/internal/urls-loader"
@@ -54,6 +56,15 @@ func main() {
 
 	logger.Info("Mongodb initialized")
 
+	if err := mongodb.Migrate(ctx); err != nil {
+		logger.Error("Failed to migrate mongodb",
+			zap.Error(err),
+		)
+		os.Exit(1)
+	}
+
+	logger.Info("Mongodb migrated")
+
 	defer func() {
 		closeCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		defer cancel()
@@ -66,7 +77,7 @@ func main() {
 		logger.Info("Mognodb closed")
 	}()
 
-	urls, err := urlsloader.Load(cfg.URLs)
+	tables, err := urlsloader.Load(cfg.URLs)
 	if err != nil {
 		logger.Error("Failed to load urls",
 			zap.Error(err),
@@ -74,19 +85,34 @@ func main() {
 		os.Exit(1)
 	}
 
-	reader := csvreader.New()
+	urls := tables.URLs()
+
+	httpReader := csvreader.New(cfg.ConfigReader)
+	reader := csvreader.NewRouter(map[string]csvreader.Reader{
+		"http":  httpReader,
+		"https": httpReader,
+		"file":  csvreader.NewFileReader(),
+	})
 
 	versionParser := versionparser.New(reader)
-	versionService := versionservice.New(mongodb, mongodb, versionParser, urls["version"])
+	versionService := versionservice.New(mongodb, versionParser, urls["version"])
 
 	weaponsParser := weaponsparser.New(reader, &weaponmapper.WeaponMapper{})
-	weaponsAggregator := weaponsaggregator.New(urls, weaponsParser, logger)
-	weaponsService := weaponsservice.New(mongodb, mongodb, weaponsAggregator, versionService)
+	weaponsAggregator := weaponsaggregator.New(tables, weaponsParser, logger, cfg.Workers)
+	weaponsService := weaponsservice.New(mongodb, mongodb, weaponsAggregator, versionService, mongodb, mongodb, weaponsuggester.New(), mongodb)
+
+	if err := weaponsService.RebuildSuggestions(ctx); err != nil {
+		logger.Warn("Failed to build suggestions index",
+			zap.Error(err),
+		)
+	}
 
-	observer := observer.New(versionService, versionParser, weaponsService, logger, urls["version"])
+	observer := observer.New(versionService, versionParser, weaponsService, weaponsAggregator, logger, tables, cfg.ConfigUpdate)
 	go observer.Observe(ctx)
 
-	server := server.New(weaponsService, versionService, urls, logger)
+	categoriesService := categoriesservice.New(tables, mongodb)
+
+	server := server.New(weaponsService, versionService, categoriesService, urls, logger)
 	if err := server.Run(ctx, cfg); err != nil {
 		logger.Error("Server error",
 			zap.Error(err),
@@ -12,6 +12,8 @@ type Config struct {
 	URLs          string `yaml:"urls"`
 	ConfigServer  `yaml:"server"`
 	ConfigMongoDB `yaml:"mongodb"`
+	ConfigReader  `yaml:"reader"`
+	ConfigUpdate  `yaml:"update"`
 }
 
 type ConfigServer struct {
@@ -28,10 +30,30 @@ type ConfigMongoDB struct {
 	Port           string        `yaml:"port"`
 	DBName         string        `yaml:"db_name"`
 	CollName       string        `yaml:"coll_name"`
+	HistoryColl    string        `yaml:"history_coll_name" env-default:"history"`
+	SchemaColl     string        `yaml:"schema_coll_name" env-default:"schema_reports"`
+	MergesColl     string        `yaml:"merges_coll_name" env-default:"merges"`
+	VersionsColl   string        `yaml:"versions_coll_name" env-default:"versions"`
+	MigrationsColl string        `yaml:"migrations_coll_name" env-default:"migrations"`
 	ConnectTimeout time.Duration `yaml:"conn_timeout"`
 	SelectTimeout  time.Duration `yaml:"select_timeout"`
 }
 
+type ConfigReader struct {
+	Timeout        time.Duration `yaml:"timeout"`
+	Retries        int           `yaml:"retries"`
+	BackoffBase    time.Duration `yaml:"backoff_base"`
+	BackoffMax     time.Duration `yaml:"backoff_max"`
+	RequestsPerSec float64       `yaml:"requests_per_sec"`
+}
+
+type ConfigUpdate struct {
+	Partial bool          `yaml:"partial"`
+	Workers int           `yaml:"workers"`
+	Period  time.Duration `yaml:"period"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 func MustLoad(path string) *Config {
 	cfg := new(Config)
 
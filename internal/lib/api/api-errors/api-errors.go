@@ -32,3 +32,19 @@ func EmptySearchResults() APIError {
 func FailUpdateWeapons() APIError {
 	return NewApiError(http.StatusInternalServerError, fmt.Errorf("failed to update weapons"))
 }
+
+func WeaponNotFound(id string) APIError {
+	return NewApiError(http.StatusNotFound, fmt.Errorf("weapon %s not found", id))
+}
+
+func MissingQueryParam(param string) APIError {
+	return NewApiError(http.StatusBadRequest, fmt.Errorf("query parameter %s is required", param))
+}
+
+func NotFound(err error) APIError {
+	return NewApiError(http.StatusNotFound, err)
+}
+
+func InvalidQueryParam(param string, err error) APIError {
+	return NewApiError(http.StatusBadRequest, fmt.Errorf("invalid query parameter %s: %w", param, err))
+}
@@ -2,34 +2,114 @@ package csvreader
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/erknas/wt-guided-weapons/internal/config"
 )
 
+const defaultTimeout = 10 * time.Second
+
+var ErrRetryDeadline = errors.New("retry delay exceeds deadline")
+
 type Reader interface {
 	Read(ctx context.Context, url string) ([][]string, error)
 }
 
+type cacheEntry struct {
+	etag         string
+	lastModified string
+	data         [][]string
+}
+
+type statusError struct {
+	code       int
+	status     string
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, status: %s", e.code, e.status)
+}
+
 type HTTPReader struct {
-	client *http.Client
+	client      *http.Client
+	limiter     *hostLimiter
+	retries     int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	cache       map[string]cacheEntry
+	mu          sync.RWMutex
 }
 
-func New() *HTTPReader {
+func New(cfg config.ConfigReader) *HTTPReader {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
 	return &HTTPReader{
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: timeout,
 			Transport: &http.Transport{
 				MaxIdleConns:        10,
 				MaxIdleConnsPerHost: 15,
 				IdleConnTimeout:     90 * time.Second,
 			},
 		},
+		limiter:     newHostLimiter(cfg.RequestsPerSec),
+		retries:     max(cfg.Retries, 0),
+		backoffBase: cfg.BackoffBase,
+		backoffMax:  max(cfg.BackoffMax, cfg.BackoffBase),
+		cache:       make(map[string]cacheEntry),
+	}
+}
+
+func (r *HTTPReader) Read(ctx context.Context, rawURL string) ([][]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := r.limiter.Wait(ctx, u.Host); err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+
+		data, err := r.fetch(ctx, rawURL)
+		if err == nil {
+			return data, nil
+		}
+
+		if attempt >= r.retries || !retryable(ctx, err) {
+			return nil, err
+		}
+
+		delay := r.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrRetryDeadline, delay, err)
+		}
+
+		addRetry(ctx)
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("failed to wait for retry: %w", err)
+		}
 	}
 }
 
-func (r *HTTPReader) Read(ctx context.Context, url string) ([][]string, error) {
+func (r *HTTPReader) fetch(ctx context.Context, url string) ([][]string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new request: %w", err)
@@ -37,17 +117,119 @@ func (r *HTTPReader) Read(ctx context.Context, url string) ([][]string, error) {
 
 	req.Header.Set("Accept", "text/csv, application/csv, text/plain")
 
+	r.mu.RLock()
+	cached, ok := r.cache[url]
+	r.mu.RUnlock()
+
+	if ok {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
 	resp, err := r.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && ok {
+		return cached.data, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, status: %s", resp.StatusCode, resp.Status)
+		return nil, &statusError{
+			code:       resp.StatusCode,
+			status:     resp.Status,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	data, err := readCSV(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := cacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		data:         data,
 	}
 
-	reader := csv.NewReader(resp.Body)
+	if entry.etag != "" || entry.lastModified != "" {
+		r.mu.Lock()
+		r.cache[url] = entry
+		r.mu.Unlock()
+	}
+
+	return data, nil
+}
+
+func (r *HTTPReader) backoff(attempt int, err error) time.Duration {
+	delay := r.backoffBase << attempt
+	if delay <= 0 || delay > r.backoffMax {
+		delay = r.backoffMax
+	}
+
+	delay = delay/2 + rand.N(delay/2+1)
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		delay = max(delay, statusErr.retryAfter)
+	}
+
+	return delay
+}
+
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+
+	return 0
+}
+
+func Hash(data [][]string) string {
+	h := sha256.New()
+
+	for _, record := range data {
+		for _, field := range record {
+			h.Write([]byte(field))
+			h.Write([]byte{0x1f})
+		}
+		h.Write([]byte{0x1e})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
 	reader.FieldsPerRecord = -1
 
 	data, err := reader.ReadAll()
@@ -2,9 +2,12 @@ package csvreader
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/erknas/wt-guided-weapons/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,7 +69,7 @@ func TestRead(t *testing.T) {
 				ctx = tt.ctx()
 			}
 
-			reader := New()
+			reader := New(config.ConfigReader{})
 
 			res, err := reader.Read(ctx, tt.url)
 
@@ -81,3 +84,215 @@ func TestRead(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPReader_ConditionalRead(t *testing.T) {
+	const etag = `"v1"`
+
+	hits, notModified := 0, 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte("Name:,AIM-9L\n"))
+	}))
+	defer srv.Close()
+
+	reader := New(config.ConfigReader{})
+
+	first, err := reader.Read(context.Background(), srv.URL)
+	require.NoError(t, err)
+
+	second, err := reader.Read(context.Background(), srv.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, notModified)
+}
+
+func TestHash(t *testing.T) {
+	a := [][]string{{"Name:", "AIM-9L"}, {"Mass:", "85"}}
+	b := [][]string{{"Name:", "AIM-9L"}, {"Mass:", "86"}}
+	c := [][]string{{"Name:", "AIM-9L", "Mass:", "85"}}
+
+	assert.Equal(t, Hash(a), Hash([][]string{{"Name:", "AIM-9L"}, {"Mass:", "85"}}))
+	assert.NotEqual(t, Hash(a), Hash(b))
+	assert.NotEqual(t, Hash(a), Hash(c))
+}
+
+func TestHTTPReader_Retry(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		retries     int
+		wantErr     bool
+		wantHits    int
+		wantRetries int
+	}{
+		{
+			name:        "success after transient errors",
+			statuses:    []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			retries:     3,
+			wantHits:    3,
+			wantRetries: 2,
+		},
+		{
+			name:        "retries exhausted",
+			statuses:    []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			retries:     2,
+			wantErr:     true,
+			wantHits:    3,
+			wantRetries: 2,
+		},
+		{
+			name:     "not retryable status",
+			statuses: []int{http.StatusNotFound, http.StatusOK},
+			retries:  3,
+			wantErr:  true,
+			wantHits: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[hits]
+				hits++
+				if status != http.StatusOK {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte("Name:,AIM-9L\n"))
+			}))
+			defer srv.Close()
+
+			reader := New(config.ConfigReader{
+				Retries:     tt.retries,
+				BackoffBase: time.Millisecond,
+				BackoffMax:  5 * time.Millisecond,
+			})
+
+			ctx, stats := WithStats(context.Background())
+
+			res, err := reader.Read(ctx, srv.URL)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, [][]string{{"Name:", "AIM-9L"}}, res)
+			}
+
+			assert.Equal(t, tt.wantHits, hits)
+			assert.Equal(t, tt.wantRetries, stats.Retries())
+		})
+	}
+}
+
+func TestHTTPReader_Backoff(t *testing.T) {
+	reader := New(config.ConfigReader{
+		BackoffBase: time.Millisecond,
+		BackoffMax:  5 * time.Millisecond,
+	})
+
+	tests := []struct {
+		name    string
+		err     error
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			name:    "computed delay",
+			err:     &statusError{code: http.StatusBadGateway},
+			wantMin: 0,
+			wantMax: 5 * time.Millisecond,
+		},
+		{
+			name:    "retry after below computed delay",
+			err:     &statusError{code: http.StatusTooManyRequests, retryAfter: time.Nanosecond},
+			wantMin: 0,
+			wantMax: 5 * time.Millisecond,
+		},
+		{
+			name:    "retry after above backoff max",
+			err:     &statusError{code: http.StatusTooManyRequests, retryAfter: time.Minute},
+			wantMin: time.Minute,
+			wantMax: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay := reader.backoff(2, tt.err)
+			assert.GreaterOrEqual(t, delay, tt.wantMin)
+			assert.LessOrEqual(t, delay, tt.wantMax)
+		})
+	}
+}
+
+func TestHTTPReader_RetryAfterDeadline(t *testing.T) {
+	hits := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	reader := New(config.ConfigReader{
+		Retries:     3,
+		BackoffBase: time.Millisecond,
+		BackoffMax:  5 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ctx, stats := WithStats(ctx)
+
+	start := time.Now()
+	_, err := reader.Read(ctx, srv.URL)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRetryDeadline)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, hits)
+	assert.Zero(t, stats.Retries())
+}
+
+func TestHTTPReader_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Name:,AIM-9L\n"))
+	}))
+	defer srv.Close()
+
+	reader := New(config.ConfigReader{RequestsPerSec: 20})
+
+	start := time.Now()
+	for range 3 {
+		_, err := reader.Read(context.Background(), srv.URL)
+		require.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+
+	delay := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Minute), float64(delay), float64(2*time.Second))
+}
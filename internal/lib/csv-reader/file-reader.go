@@ -0,0 +1,34 @@
+package csvreader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const FileScheme = "file://"
+
+type FileReader struct{}
+
+func NewFileReader() *FileReader {
+	return &FileReader{}
+}
+
+func (r *FileReader) Read(ctx context.Context, url string) ([][]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(FilePath(url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return readCSV(file)
+}
+
+func FilePath(url string) string {
+	return strings.TrimPrefix(url, FileScheme)
+}
@@ -0,0 +1,53 @@
+package csvreader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReader_Read(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "aam-arh.csv")
+	err := os.WriteFile(path, []byte("Name:,AIM-120A,AIM-120B\nMass:,152,152\n"), 0644)
+	require.NoError(t, err)
+
+	reader := NewFileReader()
+
+	t.Run("success", func(t *testing.T) {
+		data, err := reader.Read(context.Background(), FileScheme+path)
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"Name:", "AIM-120A", "AIM-120B"},
+			{"Mass:", "152", "152"},
+		}, data)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty.csv")
+		require.NoError(t, os.WriteFile(empty, nil, 0644))
+
+		data, err := reader.Read(context.Background(), FileScheme+empty)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("fail file not exists", func(t *testing.T) {
+		_, err := reader.Read(context.Background(), FileScheme+filepath.Join(dir, "missing.csv"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open file")
+	})
+
+	t.Run("fail context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := reader.Read(ctx, FileScheme+path)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,58 @@
+package csvreader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type hostLimiter struct {
+	interval time.Duration
+	next     map[string]time.Time
+	mu       sync.Mutex
+}
+
+func newHostLimiter(requestsPerSec float64) *hostLimiter {
+	var interval time.Duration
+	if requestsPerSec > 0 {
+		interval = time.Duration(float64(time.Second) / requestsPerSec)
+	}
+
+	return &hostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+func (l *hostLimiter) Wait(ctx context.Context, host string) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleep(ctx, time.Until(slot))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,31 @@
+package csvreader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Router struct {
+	readers map[string]Reader
+}
+
+func NewRouter(readers map[string]Reader) *Router {
+	return &Router{
+		readers: readers,
+	}
+}
+
+func (r *Router) Read(ctx context.Context, url string) ([][]string, error) {
+	scheme, _, ok := strings.Cut(url, "://")
+	if !ok {
+		return nil, fmt.Errorf("missing scheme in %s", url)
+	}
+
+	reader, ok := r.readers[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported scheme %s", scheme)
+	}
+
+	return reader.Read(ctx, url)
+}
@@ -0,0 +1,38 @@
+package csvreader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReader struct {
+	data [][]string
+}
+
+func (r stubReader) Read(ctx context.Context, url string) ([][]string, error) {
+	return r.data, nil
+}
+
+func TestRouter_Read(t *testing.T) {
+	router := NewRouter(map[string]Reader{
+		"https": stubReader{data: [][]string{{"http"}}},
+		"file":  stubReader{data: [][]string{{"file"}}},
+	})
+
+	data, err := router.Read(context.Background(), "https://example.com/sheet.csv")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"http"}}, data)
+
+	data, err = router.Read(context.Background(), "file://data/aam-arh.csv")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"file"}}, data)
+
+	_, err = router.Read(context.Background(), "ftp://example.com/sheet.csv")
+	assert.ErrorContains(t, err, "unsupported scheme ftp")
+
+	_, err = router.Read(context.Background(), "data/aam-arh.csv")
+	assert.ErrorContains(t, err, "missing scheme")
+}
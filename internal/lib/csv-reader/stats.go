@@ -0,0 +1,27 @@
+package csvreader
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type statsKey struct{}
+
+type Stats struct {
+	retries atomic.Int64
+}
+
+func WithStats(ctx context.Context) (context.Context, *Stats) {
+	stats := new(Stats)
+	return context.WithValue(ctx, statsKey{}, stats), stats
+}
+
+func (s *Stats) Retries() int {
+	return int(s.retries.Load())
+}
+
+func addRetry(ctx context.Context) {
+	if stats, ok := ctx.Value(statsKey{}).(*Stats); ok {
+		stats.retries.Add(1)
+	}
+}
@@ -0,0 +1,49 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	prefix    = "offset:"
+	separator = ";limit:"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+func Encode(offset, limit int) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%s%d%s%d", prefix, offset, separator, limit))
+}
+
+func Decode(cursor string) (int, int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+
+	raw, ok := strings.CutPrefix(string(data), prefix)
+	if !ok {
+		return 0, 0, ErrInvalidCursor
+	}
+
+	rawOffset, rawLimit, ok := strings.Cut(raw, separator)
+	if !ok {
+		return 0, 0, ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(rawOffset)
+	if err != nil || offset < 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+
+	limit, err := strconv.Atoi(rawLimit)
+	if err != nil || limit < 1 {
+		return 0, 0, ErrInvalidCursor
+	}
+
+	return offset, limit, nil
+}
@@ -0,0 +1,39 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, offset := range []int{0, 1, 50, 1000} {
+		resOffset, resLimit, err := Decode(Encode(offset, 25))
+		require.NoError(t, err)
+		assert.Equal(t, offset, resOffset)
+		assert.Equal(t, 25, resLimit)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "missing prefix", cursor: "MTA"},
+		{name: "not a number", cursor: "b2Zmc2V0OmFiYw"},
+		{name: "negative", cursor: Encode(-5, 10)},
+		{name: "missing limit", cursor: base64.RawURLEncoding.EncodeToString([]byte("offset:10"))},
+		{name: "zero limit", cursor: Encode(10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Decode(tt.cursor)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
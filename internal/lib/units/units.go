@@ -0,0 +1,156 @@
+package units
+
+import (
+	"math"
+	"sort"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+const (
+	Metric   = "metric"
+	Imperial = "imperial"
+	Raw      = "raw"
+)
+
+type conversion struct {
+	unit   string
+	factor float64
+}
+
+var toSI = map[string]conversion{
+	"km":   {unit: "m", factor: 1000},
+	"mm":   {unit: "m", factor: 0.001},
+	"Mach": {unit: "m/s", factor: 343},
+}
+
+var toImperial = map[string]conversion{
+	"m":    {unit: "ft", factor: 3.28084},
+	"m/s":  {unit: "ft/s", factor: 3.28084},
+	"m/s²": {unit: "ft/s²", factor: 3.28084},
+	"kg":   {unit: "lb", factor: 2.20462},
+	"N":    {unit: "lbf", factor: 0.224809},
+}
+
+var variants = map[string]string{
+	"minimum_range_km":           "minimum_range",
+	"statcard_speed_ms":          "statcard_speed",
+	"statcard_speed_mach":        "statcard_speed",
+	"distance_min_m":             "distance_min",
+	"distance_max_km":            "distance_max",
+	"distance_width_m":           "distance_width",
+	"distance_ref_width_m":       "distance_ref_width",
+	"distance_min_signal_gate_m": "distance_min_signal_gate",
+	"inertial_guidance_drift_ms": "inertial_guidance_drift",
+}
+
+var implicit = map[string]string{
+	"distance_min":             "m",
+	"distance_max":             "m",
+	"distance_width":           "m",
+	"distance_ref_width":       "m",
+	"distance_min_signal_gate": "m",
+	"inertial_guidance_drift":  "m/s",
+}
+
+func Valid(system string) bool {
+	switch system {
+	case Metric, Imperial, Raw:
+		return true
+	default:
+		return false
+	}
+}
+
+func Canonical(key string) string {
+	if canonical, ok := variants[key]; ok {
+		return canonical
+	}
+	return key
+}
+
+func Normalize(stats types.Stats) types.Stats {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	quantities := make(types.Stats, len(stats))
+	ranks := make(map[string]int, len(stats))
+
+	for _, key := range keys {
+		stat := stats[key]
+		canonical := Canonical(key)
+
+		rank := sourceRank(stat.Unit)
+		if stat.Unit == "" && stat.Bool == nil {
+			stat.Unit = implicit[canonical]
+		}
+
+		if _, ok := quantities[canonical]; ok && ranks[canonical] >= rank {
+			continue
+		}
+
+		quantities[canonical] = convert(stat, toSI)
+		ranks[canonical] = rank
+	}
+
+	return quantities
+}
+
+func sourceRank(unit string) int {
+	if unit == "" {
+		return 0
+	}
+	if _, ok := toSI[unit]; ok {
+		return 1
+	}
+	return 2
+}
+
+func Convert(quantities types.Stats, system string) types.Stats {
+	switch system {
+	case Raw:
+		return nil
+	case Imperial:
+		converted := make(types.Stats, len(quantities))
+		for key, stat := range quantities {
+			converted[key] = convert(stat, toImperial)
+		}
+		return converted
+	default:
+		return quantities
+	}
+}
+
+func convert(stat types.Stat, table map[string]conversion) types.Stat {
+	c, ok := table[stat.Unit]
+	if !ok {
+		return stat
+	}
+
+	result := types.Stat{Unit: c.unit}
+
+	if stat.Value != nil {
+		value := round(*stat.Value * c.factor)
+		result.Value = &value
+	}
+
+	if len(stat.Range) > 0 {
+		result.Range = make([]float64, len(stat.Range))
+		for i, value := range stat.Range {
+			result.Range[i] = round(value * c.factor)
+		}
+	}
+
+	return result
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
@@ -0,0 +1,133 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	boolean := func(v bool) *bool { return &v }
+
+	tests := []struct {
+		name  string
+		stats types.Stats
+		want  types.Stats
+	}{
+		{
+			name: "empty",
+			want: nil,
+		},
+		{
+			name: "km to m",
+			stats: types.Stats{
+				"minimum_range_km": {Value: float(0.5), Unit: "km"},
+			},
+			want: types.Stats{
+				"minimum_range": {Value: float(500), Unit: "m"},
+			},
+		},
+		{
+			name: "mach to m/s",
+			stats: types.Stats{
+				"statcard_speed_mach": {Value: float(4), Unit: "Mach"},
+			},
+			want: types.Stats{
+				"statcard_speed": {Value: float(1372), Unit: "m/s"},
+			},
+		},
+		{
+			name: "range in mm",
+			stats: types.Stats{
+				"caliber": {Range: []float64{127, 152}, Unit: "mm"},
+			},
+			want: types.Stats{
+				"caliber": {Range: []float64{0.127, 0.152}, Unit: "m"},
+			},
+		},
+		{
+			name: "explicit unit wins",
+			stats: types.Stats{
+				"distance_max":    {Value: float(1)},
+				"distance_max_km": {Value: float(20), Unit: "km"},
+			},
+			want: types.Stats{
+				"distance_max": {Value: float(20000), Unit: "m"},
+			},
+		},
+		{
+			name: "SI source wins over converted",
+			stats: types.Stats{
+				"statcard_speed_mach": {Value: float(4), Unit: "Mach"},
+				"statcard_speed_ms":   {Value: float(1400), Unit: "m/s"},
+			},
+			want: types.Stats{
+				"statcard_speed": {Value: float(1400), Unit: "m/s"},
+			},
+		},
+		{
+			name: "converted source wins over implicit",
+			stats: types.Stats{
+				"distance_min":     {Value: float(1)},
+				"distance_min_m":   {Value: float(150), Unit: "m"},
+				"minimum_range":    {Value: float(1)},
+				"minimum_range_km": {Value: float(0.5), Unit: "km"},
+			},
+			want: types.Stats{
+				"distance_min":  {Value: float(150), Unit: "m"},
+				"minimum_range": {Value: float(500), Unit: "m"},
+			},
+		},
+		{
+			name: "implicit unit",
+			stats: types.Stats{
+				"distance_max": {Value: float(15000)},
+			},
+			want: types.Stats{
+				"distance_max": {Value: float(15000), Unit: "m"},
+			},
+		},
+		{
+			name: "bool untouched",
+			stats: types.Stats{
+				"datalink": {Bool: boolean(true)},
+			},
+			want: types.Stats{
+				"datalink": {Bool: boolean(true)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.stats))
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	value := 1000.0
+	quantities := types.Stats{
+		"minimum_range": {Value: &value, Unit: "m"},
+		"burn_time":     {Value: &value, Unit: "s"},
+	}
+
+	assert.Equal(t, quantities, Convert(quantities, Metric))
+	assert.Nil(t, Convert(quantities, Raw))
+
+	imperial := Convert(quantities, Imperial)
+	require.NotNil(t, imperial["minimum_range"].Value)
+	assert.Equal(t, 3280.84, *imperial["minimum_range"].Value)
+	assert.Equal(t, "ft", imperial["minimum_range"].Unit)
+	assert.Equal(t, quantities["burn_time"], imperial["burn_time"])
+	assert.Equal(t, 1000.0, *quantities["minimum_range"].Value)
+}
+
+func TestCanonical(t *testing.T) {
+	assert.Equal(t, "minimum_range", Canonical("minimum_range_km"))
+	assert.Equal(t, "minimum_range", Canonical("minimum_range"))
+	assert.Equal(t, "mass", Canonical("mass"))
+}
@@ -0,0 +1,157 @@
+package weaponfields
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+const ExtraPrefix = "extra."
+
+type Field struct {
+	Key    string
+	BSON   string
+	Unit   string
+	Text   bool
+	Labels []string
+	index  int
+}
+
+var (
+	fields  = load()
+	byKey   = index(fields)
+	byLabel = indexLabels(fields)
+)
+
+func load() []Field {
+	t := reflect.TypeOf(types.Weapon{})
+
+	fields := make([]Field, 0, t.NumField())
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Type.Kind() != reflect.String {
+			continue
+		}
+
+		sheet := f.Tag.Get("sheet")
+		if sheet == "" || sheet == "-" {
+			continue
+		}
+
+		key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		path, _, _ := strings.Cut(f.Tag.Get("bson"), ",")
+		if path == "" {
+			path = key
+		}
+
+		labels := strings.Split(sheet, "|")
+
+		fields = append(fields, Field{
+			Key:    key,
+			BSON:   path,
+			Unit:   f.Tag.Get("unit"),
+			Text:   f.Tag.Get("kind") == "text",
+			Labels: labels,
+			index:  i,
+		})
+	}
+
+	return fields
+}
+
+func index(fields []Field) map[string]Field {
+	byKey := make(map[string]Field, len(fields))
+
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	return byKey
+}
+
+func indexLabels(fields []Field) map[string]Field {
+	byLabel := make(map[string]Field)
+
+	for _, field := range fields {
+		for _, label := range field.Labels {
+			byLabel[label] = field
+		}
+	}
+
+	return byLabel
+}
+
+func Fields() []Field {
+	return fields
+}
+
+func Lookup(key string) (Field, bool) {
+	field, ok := byKey[key]
+	return field, ok
+}
+
+func LookupLabel(label string) (Field, bool) {
+	field, ok := byLabel[label]
+	return field, ok
+}
+
+func (f Field) Value(weapon *types.Weapon) string {
+	return reflect.ValueOf(weapon).Elem().Field(f.index).String()
+}
+
+func Diff(prev, curr *types.Weapon) []types.FieldChange {
+	if prev == nil {
+		prev = &types.Weapon{}
+	}
+	if curr == nil {
+		curr = &types.Weapon{}
+	}
+
+	var changes []types.FieldChange
+
+	for _, field := range fields {
+		oldValue, newValue := field.Value(prev), field.Value(curr)
+		if oldValue != newValue {
+			changes = append(changes, types.FieldChange{
+				Field: field.Key,
+				Old:   oldValue,
+				New:   newValue,
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(prev.Extra)+len(curr.Extra))
+	for key := range prev.Extra {
+		keys = append(keys, key)
+	}
+	for key := range curr.Extra {
+		if _, ok := prev.Extra[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		oldValue, newValue := prev.Extra[key], curr.Extra[key]
+		if oldValue != newValue {
+			changes = append(changes, types.FieldChange{
+				Field: ExtraPrefix + key,
+				Old:   oldValue,
+				New:   newValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+func (f Field) Set(weapon *types.Weapon, value string) {
+	reflect.ValueOf(weapon).Elem().Field(f.index).SetString(value)
+}
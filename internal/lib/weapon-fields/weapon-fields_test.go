@@ -0,0 +1,143 @@
+package weaponfields
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	fields := Fields()
+
+	require.NotEmpty(t, fields)
+	assert.Equal(t, "name", fields[0].Key)
+
+	for _, field := range fields {
+		assert.NotEmpty(t, field.Labels)
+		assert.NotEmpty(t, field.BSON)
+		assert.NotContains(t, []string{"id", "category", "retired_in"}, field.Key)
+	}
+}
+
+func TestLookupLabel(t *testing.T) {
+	field, ok := LookupLabel("Mass: [kg]")
+	require.True(t, ok)
+	assert.Equal(t, "mass", field.Key)
+
+	primary, ok := LookupLabel("IRCCM rejection treshold:")
+	require.True(t, ok)
+	alias, ok := LookupLabel("IRCCM rejection threshold:")
+	require.True(t, ok)
+	assert.Equal(t, primary.Key, alias.Key)
+	assert.Equal(t, []string{"IRCCM rejection treshold:", "IRCCM rejection threshold:"}, alias.Labels)
+
+	_, ok = LookupLabel("Unknown row:")
+	assert.False(t, ok)
+
+	_, ok = Lookup("category")
+	assert.False(t, ok)
+}
+
+func TestLookup(t *testing.T) {
+	weapon := &types.Weapon{Name: "AIM-9L", Mass: "85.5"}
+
+	tests := []struct {
+		name      string
+		key       string
+		wantOK    bool
+		wantUnit  string
+		wantValue string
+	}{
+		{
+			name:      "numeric field",
+			key:       "mass",
+			wantOK:    true,
+			wantUnit:  "kg",
+			wantValue: "85.5",
+		},
+		{
+			name:      "text field",
+			key:       "name",
+			wantOK:    true,
+			wantValue: "AIM-9L",
+		},
+		{
+			name:   "unknown field",
+			key:    "unknown",
+			wantOK: false,
+		},
+		{
+			name:   "id is not a field",
+			key:    "id",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, ok := Lookup(tt.key)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantUnit, field.Unit)
+				assert.Equal(t, tt.wantValue, field.Value(weapon))
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	prev := &types.Weapon{Category: "aam-arh", Name: "AIM-120A", Mass: "152", GuidanceRange: "50"}
+	curr := &types.Weapon{Category: "aam-arh", Name: "AIM-120A", Mass: "157", Loft: "Yes"}
+
+	tests := []struct {
+		name string
+		prev *types.Weapon
+		curr *types.Weapon
+		want []types.FieldChange
+	}{
+		{
+			name: "changed fields",
+			prev: prev,
+			curr: curr,
+			want: []types.FieldChange{
+				{Field: "mass", Old: "152", New: "157"},
+				{Field: "guidance_range", Old: "50"},
+				{Field: "loft", New: "Yes"},
+			},
+		},
+		{
+			name: "no previous snapshot",
+			prev: nil,
+			curr: &types.Weapon{Name: "AIM-120A", Mass: "152"},
+			want: []types.FieldChange{
+				{Field: "name", New: "AIM-120A"},
+				{Field: "mass", New: "152"},
+			},
+		},
+		{
+			name: "identical",
+			prev: prev,
+			curr: prev,
+			want: nil,
+		},
+		{
+			name: "extra attributes",
+			prev: &types.Weapon{Name: "AIM-120A", Extra: map[string]string{"Datalink range: [km]": "40", "Old row:": "1"}},
+			curr: &types.Weapon{Name: "AIM-120A", Extra: map[string]string{"Datalink range: [km]": "45", "New row:": "2"}},
+			want: []types.FieldChange{
+				{Field: "extra.Datalink range: [km]", Old: "40", New: "45"},
+				{Field: "extra.New row:", New: "2"},
+				{Field: "extra.Old row:", Old: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Diff(tt.prev, tt.curr))
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package mongodb
+package weaponid
 
 import (
 	"crypto/sha256"
@@ -7,7 +7,7 @@ import (
 	"github.com/erknas/wt-guided-weapons/internal/types"
 )
 
-func generateWeaponID(weapon *types.Weapon) string {
+func Generate(weapon *types.Weapon) string {
 	data := fmt.Sprintf("%s-%s-%s", weapon.Name, weapon.Category, weapon.AdditionalNotes)
 	hash := sha256.Sum256([]byte(data))
 	return fmt.Sprintf("%x", hash)[:16]
@@ -1,4 +1,4 @@
-package mongodb
+package weaponid
 
 import (
 	"testing"
@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGenerateWeaponID(t *testing.T) {
+func TestGenerate(t *testing.T) {
 	weapon1 := &types.Weapon{
 		Name:            "Kh-29L",
 		Category:        "agm-salh",
@@ -20,8 +20,8 @@ func TestGenerateWeaponID(t *testing.T) {
 		AdditionalNotes: "Variant used by everything else",
 	}
 
-	weaponID1 := generateWeaponID(weapon1)
-	weaponID2 := generateWeaponID(weapon2)
+	weaponID1 := Generate(weapon1)
+	weaponID2 := Generate(weapon2)
 
 	assert.Len(t, weaponID1, 16)
 	assert.Len(t, weaponID2, 16)
@@ -1,31 +1,59 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/erknas/wt-guided-weapons/internal/lib/api"
 	apierrors "github.com/erknas/wt-guided-weapons/internal/lib/api/api-errors"
 	"github.com/erknas/wt-guided-weapons/internal/logger"
+	weaponsservice "github.com/erknas/wt-guided-weapons/internal/services/weapons-service"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
-const searchQuery = "name"
+const (
+	searchQuery         = "name"
+	maxCompareIDs       = 10
+	compareQuery        = "ids"
+	suggestQuery        = "q"
+	partialQuery        = "partial"
+	versionQuery        = "version"
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 25
+)
 
 func (s *Server) handleUpdateWeapons(w http.ResponseWriter, r *http.Request) error {
 	log := logger.FromContext(r.Context(), logger.Transport)
 
-	if err := s.weapons.UpdateWeapons(r.Context()); err != nil {
+	req := types.UpdateRequest{Trigger: types.TriggerManual}
+
+	if raw := r.URL.Query().Get(partialQuery); raw != "" {
+		partial, err := strconv.ParseBool(raw)
+		if err != nil {
+			return apierrors.InvalidQueryParam(partialQuery, err)
+		}
+		req.Partial = partial
+	}
+
+	result, err := s.weapons.UpdateWeapons(r.Context(), req)
+	if err != nil {
 		log.Error("UpdateWeapons error",
 			zap.Error(err),
 		)
 		return apierrors.FailUpdateWeapons()
 	}
 
-	log.Info("UpdateWeapons handler complited")
+	log.Info("UpdateWeapons handler complited",
+		zap.Bool("partial", result.Partial),
+		zap.Int("retries", result.Retries),
+	)
 
-	return api.WriteJSON(w, http.StatusOK, nil)
+	return api.WriteJSON(w, http.StatusOK, result)
 }
 
 func (s *Server) handleGetWeaponsByCategory(w http.ResponseWriter, r *http.Request) error {
@@ -33,20 +61,123 @@ func (s *Server) handleGetWeaponsByCategory(w http.ResponseWriter, r *http.Reque
 
 	category := chi.URLParam(r, "category")
 
-	weapons, err := s.weapons.GetWeaponsByCategory(r.Context(), category)
+	query, err := parseWeaponsQuery(r.URL.Query())
+	if err != nil {
+		log.Warn("Invalid weapons query",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	query.Categories = []string{category}
+
+	system, err := parseUnits(r.URL.Query())
 	if err != nil {
-		log.Error("GetWeaponsByCategory error",
+		log.Warn("Invalid units",
 			zap.Error(err),
 		)
 		return err
 	}
 
+	weapons, err := s.weapons.GetWeapons(r.Context(), query)
+	if err != nil {
+		log.Error("GetWeapons error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	applyUnits(weapons.Weapons, system)
+
 	log.Info("GetWeaponsByCategory handler complited",
 		zap.String("category", category),
-		zap.Int("total weapons", len(weapons)),
+		zap.Int("total weapons", len(weapons.Weapons)),
 	)
 
-	return api.WriteJSON(w, http.StatusOK, types.Weapons{Weapons: weapons})
+	return api.WriteJSON(w, http.StatusOK, weapons)
+}
+
+func (s *Server) handleGetWeapons(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	query, err := parseWeaponsQuery(r.URL.Query())
+	if err != nil {
+		log.Warn("Invalid weapons query",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	categories, err := parseCategories(r.URL.Query(), s.categories)
+	if err != nil {
+		log.Warn("Invalid categories",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	query.Categories = categories
+
+	system, err := parseUnits(r.URL.Query())
+	if err != nil {
+		log.Warn("Invalid units",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	weapons, err := s.weapons.GetWeapons(r.Context(), query)
+	if err != nil {
+		log.Error("GetWeapons error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	applyUnits(weapons.Weapons, system)
+
+	log.Info("GetWeapons handler complited",
+		zap.Strings("categories", categories),
+		zap.Int("total weapons", len(weapons.Weapons)),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, weapons)
+}
+
+func (s *Server) handleGetWeaponByID(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	id := chi.URLParam(r, "id")
+
+	system, err := parseUnits(r.URL.Query())
+	if err != nil {
+		log.Warn("Invalid units",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	weapon, err := s.weapons.GetWeaponByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, weaponsservice.ErrNoWeapons) {
+			log.Warn("GetWeaponByID weapon not found",
+				zap.String("id", id),
+			)
+			return apierrors.WeaponNotFound(id)
+		}
+		log.Error("GetWeaponByID error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	applyUnits([]*types.Weapon{weapon}, system)
+
+	log.Info("GetWeaponByID handler complited",
+		zap.String("id", id),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, weapon)
 }
 
 func (s *Server) handleSeachWeapons(w http.ResponseWriter, r *http.Request) error {
@@ -54,7 +185,15 @@ func (s *Server) handleSeachWeapons(w http.ResponseWriter, r *http.Request) erro
 
 	query := chi.URLParam(r, searchQuery)
 
-	results, err := s.weapons.SearchWeapons(r.Context(), query)
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		log.Warn("Invalid search page",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	results, err := s.weapons.SearchWeapons(r.Context(), query, page)
 	if err != nil {
 		log.Error("SearchWeapons error",
 			zap.Error(err),
@@ -62,7 +201,7 @@ func (s *Server) handleSeachWeapons(w http.ResponseWriter, r *http.Request) erro
 		return err
 	}
 
-	if len(results) == 0 {
+	if len(results.Results) == 0 {
 		log.Warn("Empty search results",
 			zap.String("query", query),
 		)
@@ -71,10 +210,151 @@ func (s *Server) handleSeachWeapons(w http.ResponseWriter, r *http.Request) erro
 
 	log.Info("SearchWeapons handler complited",
 		zap.String("query", query),
-		zap.Int("total weapons found", len(results)),
+		zap.Int("total weapons found", len(results.Results)),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleSuggestWeapons(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	query := r.URL.Query().Get(suggestQuery)
+
+	limit := defaultSuggestLimit
+	if raw := r.URL.Query().Get(limitParam); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxSuggestLimit {
+			log.Warn("Invalid suggest limit",
+				zap.String("limit", raw),
+			)
+			return apierrors.InvalidQueryParam(limitParam, fmt.Errorf("must be between 1 and %d", maxSuggestLimit))
+		}
+		limit = n
+	}
+
+	suggestions := s.weapons.SuggestWeapons(r.Context(), query, limit)
+
+	log.Info("SuggestWeapons handler complited",
+		zap.String("query", query),
+		zap.Int("total suggestions", len(suggestions.Suggestions)),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, suggestions)
+}
+
+func (s *Server) handleGetWeaponHistory(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	id := chi.URLParam(r, "id")
+
+	history, err := s.weapons.GetWeaponHistory(r.Context(), id)
+	if err != nil {
+		log.Error("GetWeaponHistory error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if len(history.History) == 0 {
+		log.Warn("Empty weapon history",
+			zap.String("id", id),
+		)
+		return apierrors.WeaponNotFound(id)
+	}
+
+	log.Info("GetWeaponHistory handler complited",
+		zap.String("id", id),
+		zap.Int("total entries", len(history.History)),
 	)
 
-	return api.WriteJSON(w, http.StatusOK, types.SearchResults{Results: results})
+	return api.WriteJSON(w, http.StatusOK, history)
+}
+
+func (s *Server) handleDiffVersions(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	params := r.URL.Query()
+	from, to, category := params.Get("from"), params.Get("to"), params.Get("category")
+
+	if from == "" {
+		return apierrors.MissingQueryParam("from")
+	}
+
+	if to == "" {
+		return apierrors.MissingQueryParam("to")
+	}
+
+	if _, exists := s.categories[category]; category != "" && !exists {
+		return apierrors.InvalidCategory(category)
+	}
+
+	diff, err := s.weapons.DiffVersions(r.Context(), from, to, category)
+	if err != nil {
+		if errors.Is(err, weaponsservice.ErrNoSnapshots) {
+			log.Warn("DiffVersions no snapshots",
+				zap.Error(err),
+			)
+			return apierrors.NotFound(err)
+		}
+		log.Error("DiffVersions error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	log.Info("DiffVersions handler complited",
+		zap.String("from", from),
+		zap.String("to", to),
+		zap.String("category", category),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, diff)
+}
+
+func (s *Server) handleCompareWeapons(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	raw := r.URL.Query().Get(compareQuery)
+	if raw == "" {
+		return apierrors.MissingQueryParam(compareQuery)
+	}
+
+	ids := make([]string, 0, maxCompareIDs)
+	seen := make(map[string]struct{}, maxCompareIDs)
+
+	for _, id := range strings.Split(raw, ",") {
+		id = strings.TrimSpace(id)
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) < 2 || len(ids) > maxCompareIDs {
+		return apierrors.InvalidQueryParam(compareQuery, fmt.Errorf("expected from 2 to %d ids, got %d", maxCompareIDs, len(ids)))
+	}
+
+	comparison, err := s.weapons.CompareWeapons(r.Context(), ids)
+	if err != nil {
+		if errors.Is(err, weaponsservice.ErrNoWeapons) {
+			log.Warn("CompareWeapons weapons not found",
+				zap.Error(err),
+			)
+			return apierrors.NotFound(err)
+		}
+		log.Error("CompareWeapons error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	log.Info("CompareWeapons handler complited",
+		zap.Strings("ids", ids),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, comparison)
 }
 
 func (s *Server) handleGetVersion(w http.ResponseWriter, r *http.Request) error {
@@ -88,7 +368,53 @@ func (s *Server) handleGetVersion(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	log.Info("GetVersion complited")
+	log.Info("GetVersion complited",
+		zap.String("version", version.Version),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, version)
+}
+
+func (s *Server) handleGetCategories(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	categories, err := s.catalogue.GetCategories(r.Context())
+	if err != nil {
+		log.Error("GetCategories error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	log.Info("GetCategories handler complited",
+		zap.Int("total categories", len(categories.Categories)),
+	)
+
+	return api.WriteJSON(w, http.StatusOK, categories)
+}
+
+func (s *Server) handleGetSchemaReport(w http.ResponseWriter, r *http.Request) error {
+	log := logger.FromContext(r.Context(), logger.Transport)
+
+	version := r.URL.Query().Get(versionQuery)
+
+	report, err := s.weapons.GetSchemaReport(r.Context(), version)
+	if err != nil {
+		if errors.Is(err, weaponsservice.ErrNoSchema) {
+			log.Warn("GetSchemaReport report not found",
+				zap.String("version", version),
+			)
+			return apierrors.NotFound(err)
+		}
+		log.Error("GetSchemaReport error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	log.Info("GetSchemaReport handler complited",
+		zap.String("version", report.Version),
+	)
 
-	return api.WriteJSON(w, http.StatusOK, types.VersionInfo{Version: version.Version.Version})
+	return api.WriteJSON(w, http.StatusOK, report)
 }
@@ -3,13 +3,16 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/erknas/wt-guided-weapons/internal/lib/api"
 	apierrors "github.com/erknas/wt-guided-weapons/internal/lib/api/api-errors"
 	"github.com/erknas/wt-guided-weapons/internal/logger"
+	weaponsservice "github.com/erknas/wt-guided-weapons/internal/services/weapons-service"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -26,19 +29,53 @@ type mockVersionServicer struct {
 	mock.Mock
 }
 
-func (m *mockWeaponsServicer) UpdateWeapons(ctx context.Context) error {
-	args := m.Called(ctx)
-	return args.Error(0)
+type mockCategoriesServicer struct {
+	mock.Mock
 }
 
-func (m *mockWeaponsServicer) GetWeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error) {
-	args := m.Called(ctx, category)
-	return args.Get(0).([]*types.Weapon), args.Error(1)
+func (m *mockWeaponsServicer) UpdateWeapons(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(types.UpdateResult), args.Error(1)
 }
 
-func (m *mockWeaponsServicer) SearchWeapons(ctx context.Context, query string) ([]types.SearchResult, error) {
+func (m *mockWeaponsServicer) GetWeapons(ctx context.Context, query types.WeaponsQuery) (types.Weapons, error) {
 	args := m.Called(ctx, query)
-	return args.Get(0).([]types.SearchResult), args.Error(1)
+	return args.Get(0).(types.Weapons), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) GetWeaponByID(ctx context.Context, id string) (*types.Weapon, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*types.Weapon), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) SearchWeapons(ctx context.Context, query string, page types.Page) (types.SearchResults, error) {
+	args := m.Called(ctx, query, page)
+	return args.Get(0).(types.SearchResults), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) GetSchemaReport(ctx context.Context, version string) (types.SchemaReport, error) {
+	args := m.Called(ctx, version)
+	return args.Get(0).(types.SchemaReport), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) GetWeaponHistory(ctx context.Context, id string) (types.WeaponHistory, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(types.WeaponHistory), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) DiffVersions(ctx context.Context, from, to, category string) (types.VersionDiff, error) {
+	args := m.Called(ctx, from, to, category)
+	return args.Get(0).(types.VersionDiff), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) CompareWeapons(ctx context.Context, ids []string) (types.Comparison, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(types.Comparison), args.Error(1)
+}
+
+func (m *mockWeaponsServicer) SuggestWeapons(ctx context.Context, query string, limit int) types.Suggestions {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).(types.Suggestions)
 }
 
 func (m *mockVersionServicer) GetVersion(ctx context.Context) (types.LastChange, error) {
@@ -46,13 +83,83 @@ func (m *mockVersionServicer) GetVersion(ctx context.Context) (types.LastChange,
 	return args.Get(0).(types.LastChange), args.Error(1)
 }
 
+func (m *mockCategoriesServicer) GetCategories(ctx context.Context) (types.Categories, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(types.Categories), args.Error(1)
+}
+
+func TestHandleUpdateWeapons(t *testing.T) {
+	t.Run("partial", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPut, "/api/update?partial=true", nil)
+		require.NoError(t, err)
+
+		result := types.UpdateResult{
+			Version: "2.47.0.114",
+			Weapons: 2,
+			Partial: true,
+			Categories: []types.CategoryReport{
+				{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2},
+				{Category: "aam-sarh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+			},
+		}
+
+		mockWeaponsServicer.On("UpdateWeapons", mock.Anything, types.UpdateRequest{Partial: true, Trigger: types.TriggerManual}).Return(result, nil)
+
+		err = server.handleUpdateWeapons(rr, req)
+		require.NoError(t, err)
+
+		var res types.UpdateResult
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, result, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("invalid partial", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPut, "/api/update?partial=maybe", nil)
+		require.NoError(t, err)
+
+		api.MakeHTTPFunc(server.handleUpdateWeapons)(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		mockWeaponsServicer.AssertNotCalled(t, "UpdateWeapons")
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPut, "/api/update", nil)
+		require.NoError(t, err)
+
+		mockWeaponsServicer.On("UpdateWeapons", mock.Anything, types.UpdateRequest{Trigger: types.TriggerManual}).Return(types.UpdateResult{}, fmt.Errorf("failed to aggregate weapons"))
+
+		api.MakeHTTPFunc(server.handleUpdateWeapons)(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Result().StatusCode)
+	})
+}
+
 func TestHandleGetWeaponsByCategory(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockWeaponsServicer := new(mockWeaponsServicer)
 		mockVersionServicer := new(mockVersionServicer)
 		urls := map[string]string{"gbu-ir": "test-url"}
 
-		server := New(mockWeaponsServicer, mockVersionServicer, urls, zap.NewNop())
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
 
 		rr := httptest.NewRecorder()
 		req, err := http.NewRequest(http.MethodGet, "", nil)
@@ -67,7 +174,7 @@ func TestHandleGetWeaponsByCategory(t *testing.T) {
 			{Category: "gbu-ir", Name: "SPICE 2000"},
 		}
 
-		mockWeaponsServicer.On("GetWeaponsByCategory", mock.AnythingOfType("*context.valueCtx"), "gbu-ir").Return(weapons, nil)
+		mockWeaponsServicer.On("GetWeapons", mock.AnythingOfType("*context.valueCtx"), types.WeaponsQuery{Categories: []string{"gbu-ir"}}).Return(types.Weapons{Weapons: weapons}, nil)
 
 		err = server.handleGetWeaponsByCategory(rr, req)
 		require.NoError(t, err)
@@ -87,7 +194,7 @@ func TestHandleGetWeaponsByCategory(t *testing.T) {
 		mockVersionServicer := new(mockVersionServicer)
 		urls := map[string]string{"gbu-ir": "test-url"}
 
-		server := New(mockWeaponsServicer, mockVersionServicer, urls, zap.NewNop())
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
 
 		r := chi.NewRouter()
 		r.With(logger.MiddlewareCategoryCheck(server.categories)).Get("/", api.MakeHTTPFunc(server.handleGetWeaponsByCategory))
@@ -109,7 +216,264 @@ func TestHandleGetWeaponsByCategory(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
 		assert.Equal(t, resp.Message, "category gbuir does not exist")
 
-		mockWeaponsServicer.AssertNotCalled(t, "GetWeaponsByCategory")
+		mockWeaponsServicer.AssertNotCalled(t, "GetWeapons")
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+		urls := map[string]string{"gbu-ir": "test-url"}
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons/gbu-ir?sort=speed", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("category", "gbu-ir")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		api.MakeHTTPFunc(server.handleGetWeaponsByCategory)(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+		mockWeaponsServicer.AssertNotCalled(t, "GetWeapons")
+	})
+
+	t.Run("paginated projection", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+		urls := map[string]string{"gbu-ir": "test-url"}
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons/gbu-ir?limit=1&fields=name,mass", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("category", "gbu-ir")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		weapons := types.Weapons{
+			Weapons:    []*types.Weapon{{Category: "gbu-ir", Name: "SPICE 1000", Mass: "1000"}},
+			NextCursor: "b2Zmc2V0OjE",
+		}
+
+		query := types.WeaponsQuery{
+			Categories: []string{"gbu-ir"},
+			Fields:     []string{"name", "mass"},
+			Page:       types.Page{Limit: 1},
+		}
+
+		mockWeaponsServicer.On("GetWeapons", mock.AnythingOfType("*context.valueCtx"), query).Return(weapons, nil)
+
+		err = server.handleGetWeaponsByCategory(rr, req)
+		require.NoError(t, err)
+
+		var res types.Weapons
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, weapons, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+}
+
+func TestHandleGetWeapons(t *testing.T) {
+	urls := map[string]string{
+		"version":  "test-url",
+		"aam-arh":  "test-url",
+		"aam-sarh": "test-url",
+		"sam-arh":  "test-url",
+		"gbu-ir":   "test-url",
+	}
+
+	t.Run("families and guidance", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons?category=aam-*,sam-arh&guidance=ARH,SARH", nil)
+		require.NoError(t, err)
+
+		weapons := types.Weapons{
+			Weapons: []*types.Weapon{{Category: "aam-arh", Name: "AIM-120A"}},
+		}
+
+		query := types.WeaponsQuery{
+			Categories: []string{"aam-arh", "aam-sarh", "sam-arh"},
+			Guidance:   []string{"ARH", "SARH"},
+		}
+
+		mockWeaponsServicer.On("GetWeapons", mock.Anything, query).Return(weapons, nil)
+
+		err = server.handleGetWeapons(rr, req)
+		require.NoError(t, err)
+
+		var res types.Weapons
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, weapons, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("all categories", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons?warhead=HEAT", nil)
+		require.NoError(t, err)
+
+		query := types.WeaponsQuery{
+			Categories: []string{"aam-arh", "aam-sarh", "gbu-ir", "sam-arh"},
+			Warheads:   []string{"HEAT"},
+		}
+
+		mockWeaponsServicer.On("GetWeapons", mock.Anything, query).Return(types.Weapons{}, nil)
+
+		err = server.handleGetWeapons(rr, req)
+		require.NoError(t, err)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), urls, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons?category=atgm-*", nil)
+		require.NoError(t, err)
+
+		api.MakeHTTPFunc(server.handleGetWeapons)(rr, req)
+
+		var resp apierrors.APIError
+		err = json.NewDecoder(rr.Body).Decode(&resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		assert.Equal(t, "category atgm-* does not exist", resp.Message)
+
+		mockWeaponsServicer.AssertNotCalled(t, "GetWeapons")
+	})
+}
+
+func TestHandleGetWeaponByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "a1b2c3")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		weapon := &types.Weapon{ID: "a1b2c3", Category: "gbu-ir", Name: "SPICE 1000"}
+
+		mockWeaponsServicer.On("GetWeaponByID", mock.AnythingOfType("*context.valueCtx"), "a1b2c3").Return(weapon, nil)
+
+		err = server.handleGetWeaponByID(rr, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		var res types.Weapon
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, *weapon, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("imperial units", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/?units=imperial", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "a1b2c3")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		mass := 100.0
+		weapon := &types.Weapon{ID: "a1b2c3", Quantities: types.Stats{"mass": {Value: &mass, Unit: "kg"}}}
+
+		mockWeaponsServicer.On("GetWeaponByID", mock.AnythingOfType("*context.valueCtx"), "a1b2c3").Return(weapon, nil)
+
+		err = server.handleGetWeaponByID(rr, req)
+		require.NoError(t, err)
+
+		var res types.Weapon
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		require.NotNil(t, res.Quantities["mass"].Value)
+		assert.Equal(t, 220.462, *res.Quantities["mass"].Value)
+		assert.Equal(t, "lb", res.Quantities["mass"].Unit)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("invalid units", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/?units=nautical", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "a1b2c3")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		err = server.handleGetWeaponByID(rr, req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), unitsParam)
+
+		mockWeaponsServicer.AssertNotCalled(t, "GetWeaponByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("weapon not found", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "unknown")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		mockWeaponsServicer.On("GetWeaponByID", mock.AnythingOfType("*context.valueCtx"), "unknown").Return((*types.Weapon)(nil), fmt.Errorf("%w: unknown", weaponsservice.ErrNoWeapons))
+
+		err = server.handleGetWeaponByID(rr, req)
+		require.Error(t, err)
+		assert.Equal(t, apierrors.WeaponNotFound("unknown"), err)
+
+		mockWeaponsServicer.AssertExpectations(t)
 	})
 }
 
@@ -118,7 +482,7 @@ func TestHandleSearchWeapons(t *testing.T) {
 		mockWeaponsServicer := new(mockWeaponsServicer)
 		mockVersionServicer := new(mockVersionServicer)
 
-		server := New(mockWeaponsServicer, mockVersionServicer, map[string]string{}, zap.NewNop())
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
 
 		rr := httptest.NewRecorder()
 		req, err := http.NewRequest(http.MethodGet, "", nil)
@@ -129,13 +493,13 @@ func TestHandleSearchWeapons(t *testing.T) {
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 		searchResults := []types.SearchResult{
-			{Category: "gbu-ir", Name: "SPICE 1000"},
-			{Category: "gbu-ir", Name: "SPICE 2000"},
+			{ID: "a1b2c3", Category: "gbu-ir", Name: "SPICE 1000"},
+			{ID: "d4e5f6", Category: "gbu-ir", Name: "SPICE 2000"},
 		}
 
 		results := types.SearchResults{Results: searchResults}
 
-		mockWeaponsServicer.On("SearchWeapons", mock.AnythingOfType("*context.valueCtx"), "spice").Return(searchResults, nil)
+		mockWeaponsServicer.On("SearchWeapons", mock.AnythingOfType("*context.valueCtx"), "spice", types.Page{}).Return(results, nil)
 
 		err = server.handleSeachWeapons(rr, req)
 		require.NoError(t, err)
@@ -151,12 +515,252 @@ func TestHandleSearchWeapons(t *testing.T) {
 	})
 }
 
+func TestHandleSuggestWeapons(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons/suggest?q=aim&limit=5", nil)
+		require.NoError(t, err)
+
+		suggestions := types.Suggestions{Suggestions: []types.WeaponRef{
+			{ID: "1", Name: "AIM-9M", Category: "aam-ir-all-aspect"},
+		}}
+
+		mockWeaponsServicer.On("SuggestWeapons", mock.Anything, "aim", 5).Return(suggestions)
+
+		err = server.handleSuggestWeapons(rr, req)
+		require.NoError(t, err)
+
+		var res types.Suggestions
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, suggestions, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("empty results", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons/suggest?q=", nil)
+		require.NoError(t, err)
+
+		mockWeaponsServicer.On("SuggestWeapons", mock.Anything, "", defaultSuggestLimit).Return(types.Suggestions{Suggestions: []types.WeaponRef{}})
+
+		err = server.handleSuggestWeapons(rr, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		assert.JSONEq(t, `{"suggestions":[]}`, rr.Body.String())
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/weapons/suggest?q=aim&limit=100", nil)
+		require.NoError(t, err)
+
+		api.MakeHTTPFunc(server.handleSuggestWeapons)(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		mockWeaponsServicer.AssertNotCalled(t, "SuggestWeapons")
+	})
+}
+
+func TestHandleGetWeaponHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "a1b2c3")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		history := types.WeaponHistory{
+			ID:       "a1b2c3",
+			Name:     "R-77",
+			Category: "aam-arh",
+			History: []types.HistoryEntry{
+				{Version: "2.47.0.1", Changes: []types.FieldChange{{Field: "mass", Old: "175", New: "190"}}},
+			},
+		}
+
+		mockWeaponsServicer.On("GetWeaponHistory", mock.AnythingOfType("*context.valueCtx"), "a1b2c3").Return(history, nil)
+
+		err = server.handleGetWeaponHistory(rr, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		var res types.WeaponHistory
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, history, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("weapon not found", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+		mockVersionServicer := new(mockVersionServicer)
+
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.NoError(t, err)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "unknown")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		mockWeaponsServicer.On("GetWeaponHistory", mock.AnythingOfType("*context.valueCtx"), "unknown").Return(types.WeaponHistory{ID: "unknown"}, nil)
+
+		err = server.handleGetWeaponHistory(rr, req)
+		require.Error(t, err)
+		assert.Equal(t, apierrors.WeaponNotFound("unknown"), err)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+}
+
+func TestHandleDiffVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		mocks      func(*mockWeaponsServicer)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "?from=2.45&to=2.47&category=aam-arh",
+			mocks: func(mws *mockWeaponsServicer) {
+				mws.On("DiffVersions", mock.Anything, "2.45", "2.47", "aam-arh").Return(types.VersionDiff{From: "2.45", To: "2.47"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing from",
+			query:      "?to=2.47",
+			mocks:      func(mws *mockWeaponsServicer) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid category",
+			query:      "?from=2.45&to=2.47&category=aamarh",
+			mocks:      func(mws *mockWeaponsServicer) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "unknown version",
+			query: "?from=1.0&to=2.47",
+			mocks: func(mws *mockWeaponsServicer) {
+				mws.On("DiffVersions", mock.Anything, "1.0", "2.47", "").Return(types.VersionDiff{}, fmt.Errorf("%w for version 1.0", weaponsservice.ErrNoSnapshots))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeaponsServicer := new(mockWeaponsServicer)
+			mockVersionServicer := new(mockVersionServicer)
+			tt.mocks(mockWeaponsServicer)
+
+			server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{"aam-arh": "test-url"}, zap.NewNop())
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/api/diff"+tt.query, nil)
+			require.NoError(t, err)
+
+			api.MakeHTTPFunc(server.handleDiffVersions)(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Result().StatusCode)
+
+			mockWeaponsServicer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCompareWeapons(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		mocks      func(*mockWeaponsServicer)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "?ids=a1,b2,a1",
+			mocks: func(mws *mockWeaponsServicer) {
+				mws.On("CompareWeapons", mock.Anything, []string{"a1", "b2"}).Return(types.Comparison{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing ids",
+			query:      "",
+			mocks:      func(mws *mockWeaponsServicer) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "single id",
+			query:      "?ids=a1",
+			mocks:      func(mws *mockWeaponsServicer) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "weapon not found",
+			query: "?ids=a1,zz",
+			mocks: func(mws *mockWeaponsServicer) {
+				mws.On("CompareWeapons", mock.Anything, []string{"a1", "zz"}).Return(types.Comparison{}, fmt.Errorf("%w: zz", weaponsservice.ErrNoWeapons))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeaponsServicer := new(mockWeaponsServicer)
+			mockVersionServicer := new(mockVersionServicer)
+			tt.mocks(mockWeaponsServicer)
+
+			server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/api/compare"+tt.query, nil)
+			require.NoError(t, err)
+
+			api.MakeHTTPFunc(server.handleCompareWeapons)(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Result().StatusCode)
+
+			mockWeaponsServicer.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandleGetVersion(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockWeaponsServicer := new(mockWeaponsServicer)
 		mockVersionServicer := new(mockVersionServicer)
 
-		server := New(mockWeaponsServicer, mockVersionServicer, map[string]string{}, zap.NewNop())
+		server := New(mockWeaponsServicer, mockVersionServicer, new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
 
 		rr := httptest.NewRecorder()
 		req, err := http.NewRequest(http.MethodGet, "", nil)
@@ -167,7 +771,15 @@ func TestHandleGetVersion(t *testing.T) {
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 		version := types.VersionInfo{Version: "24.0.1.144"}
-		lastChange := types.LastChange{Version: version}
+		lastChange := types.LastChange{
+			VersionInfo:  version,
+			IngestedAt:   time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+			DurationMs:   1500,
+			Trigger:      types.TriggerVersion,
+			Weapons:      2,
+			Categories:   map[string]int{"aam-arh": 2},
+			SourceHashes: map[string]string{"aam-arh": "abc"},
+		}
 
 		mockVersionServicer.On("GetVersion", mock.AnythingOfType("*context.valueCtx")).Return(lastChange, nil)
 
@@ -177,10 +789,85 @@ func TestHandleGetVersion(t *testing.T) {
 		assert.Equal(t, rr.Result().StatusCode, http.StatusOK)
 
 		var res types.LastChange
-		err = json.NewDecoder(rr.Result().Body).Decode(&res.Version)
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
 		require.NoError(t, err)
 		assert.Equal(t, lastChange, res)
 
 		mockWeaponsServicer.AssertExpectations(t)
 	})
 }
+
+func TestHandleGetCategories(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCategoriesServicer := new(mockCategoriesServicer)
+
+		server := New(new(mockWeaponsServicer), new(mockVersionServicer), mockCategoriesServicer, map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/categories", nil)
+		require.NoError(t, err)
+
+		categories := types.Categories{Categories: []types.Category{
+			{Name: "aam-arh", Title: "Active radar AAM", Family: "AAM", Guidance: "ARH", Count: 12, LastUpdated: "2.47.0.1"},
+		}}
+
+		mockCategoriesServicer.On("GetCategories", mock.Anything).Return(categories, nil)
+
+		err = server.handleGetCategories(rr, req)
+		require.NoError(t, err)
+
+		var res types.Categories
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, categories, res)
+
+		mockCategoriesServicer.AssertExpectations(t)
+	})
+}
+
+func TestHandleGetSchemaReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/admin/schema-report?version=2.47.0.1", nil)
+		require.NoError(t, err)
+
+		report := types.SchemaReport{
+			Version: "2.47.0.1",
+			Categories: []types.CategorySchema{
+				{Category: "aam-ir-all-aspect", Rows: 4, Known: 3, Coverage: 0.75, Unknown: []string{"IRCCM rejection threshold:"}},
+			},
+		}
+
+		mockWeaponsServicer.On("GetSchemaReport", mock.Anything, "2.47.0.1").Return(report, nil)
+
+		err = server.handleGetSchemaReport(rr, req)
+		require.NoError(t, err)
+
+		var res types.SchemaReport
+		err = json.NewDecoder(rr.Result().Body).Decode(&res)
+		require.NoError(t, err)
+		assert.Equal(t, report, res)
+
+		mockWeaponsServicer.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockWeaponsServicer := new(mockWeaponsServicer)
+
+		server := New(mockWeaponsServicer, new(mockVersionServicer), new(mockCategoriesServicer), map[string]string{}, zap.NewNop())
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/admin/schema-report", nil)
+		require.NoError(t, err)
+
+		mockWeaponsServicer.On("GetSchemaReport", mock.Anything, "").Return(types.SchemaReport{}, weaponsservice.ErrNoSchema)
+
+		api.MakeHTTPFunc(server.handleGetSchemaReport)(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	})
+}
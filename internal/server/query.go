@@ -0,0 +1,266 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "github.com/erknas/wt-guided-weapons/internal/lib/api/api-errors"
+	"github.com/erknas/wt-guided-weapons/internal/lib/cursor"
+	"github.com/erknas/wt-guided-weapons/internal/lib/units"
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+const (
+	sortParam     = "sort"
+	filterParam   = "filter"
+	fieldsParam   = "fields"
+	limitParam    = "limit"
+	cursorParam   = "cursor"
+	categoryParam = "category"
+	guidanceParam = "guidance"
+	unitsParam    = "units"
+	retiredParam  = "include_retired"
+	warheadParam  = "warhead"
+	maxLimit      = 500
+)
+
+var reservedParams = map[string]struct{}{
+	categoryParam: {},
+	warheadParam:  {},
+}
+
+var operators = []string{
+	types.OpGte,
+	types.OpLte,
+	types.OpNe,
+	types.OpGt,
+	types.OpLt,
+	types.OpEq,
+}
+
+func parseWeaponsQuery(params url.Values) (types.WeaponsQuery, error) {
+	var query types.WeaponsQuery
+
+	page, err := parsePage(params)
+	if err != nil {
+		return types.WeaponsQuery{}, err
+	}
+	query.Page = page
+
+	for _, raw := range params[fieldsParam] {
+		for _, field := range strings.Split(raw, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" || field == "id" {
+				continue
+			}
+			if _, ok := weaponfields.Lookup(field); !ok {
+				return types.WeaponsQuery{}, apierrors.InvalidQueryParam(fieldsParam, fmt.Errorf("unknown field %q", field))
+			}
+			query.Fields = append(query.Fields, field)
+		}
+	}
+
+	if raw := params.Get(retiredParam); raw != "" {
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			return types.WeaponsQuery{}, apierrors.InvalidQueryParam(retiredParam, err)
+		}
+		query.IncludeRetired = include
+	}
+
+	query.Guidance = splitValues(params[guidanceParam])
+	query.Warheads = splitValues(params[warheadParam])
+
+	for _, raw := range params[sortParam] {
+		for _, part := range strings.Split(raw, ",") {
+			field, err := parseSort(part)
+			if err != nil {
+				return types.WeaponsQuery{}, apierrors.InvalidQueryParam(sortParam, err)
+			}
+			query.Sort = append(query.Sort, field)
+		}
+	}
+
+	for _, raw := range params[filterParam] {
+		filter, err := parseFilter(raw)
+		if err != nil {
+			return types.WeaponsQuery{}, apierrors.InvalidQueryParam(filterParam, err)
+		}
+		query.Filters = append(query.Filters, filter)
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if _, reserved := reservedParams[key]; reserved {
+			continue
+		}
+		if _, ok := weaponfields.Lookup(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range params[key] {
+			query.Filters = append(query.Filters, types.Filter{
+				Field:    key,
+				Operator: types.OpEq,
+				Value:    value,
+			})
+		}
+	}
+
+	return query, nil
+}
+
+func parseCategories(params url.Values, categories map[string]struct{}) ([]string, error) {
+	values := splitValues(params[categoryParam])
+
+	if len(values) == 0 {
+		all := make([]string, 0, len(categories))
+		for category := range categories {
+			all = append(all, category)
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+
+	resolved := make(map[string]struct{})
+
+	for _, value := range values {
+		value = strings.ToLower(value)
+
+		if family, ok := strings.CutSuffix(value, "*"); ok {
+			matched := false
+			for category := range categories {
+				if strings.HasPrefix(category, family) {
+					resolved[category] = struct{}{}
+					matched = true
+				}
+			}
+			if !matched {
+				return nil, apierrors.InvalidCategory(value)
+			}
+			continue
+		}
+
+		if _, ok := categories[value]; !ok {
+			return nil, apierrors.InvalidCategory(value)
+		}
+		resolved[value] = struct{}{}
+	}
+
+	result := make([]string, 0, len(resolved))
+	for category := range resolved {
+		result = append(result, category)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func splitValues(raw []string) []string {
+	var values []string
+
+	for _, r := range raw {
+		for _, value := range strings.Split(r, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func parseUnits(params url.Values) (string, error) {
+	system := strings.ToLower(params.Get(unitsParam))
+	if system == "" {
+		return units.Metric, nil
+	}
+
+	if !units.Valid(system) {
+		return "", apierrors.InvalidQueryParam(unitsParam, fmt.Errorf("must be one of %s, %s, %s", units.Metric, units.Imperial, units.Raw))
+	}
+
+	return system, nil
+}
+
+func applyUnits(weapons []*types.Weapon, system string) {
+	for _, weapon := range weapons {
+		weapon.Quantities = units.Convert(weapon.Quantities, system)
+	}
+}
+
+func parsePage(params url.Values) (types.Page, error) {
+	var page types.Page
+
+	if raw := params.Get(cursorParam); raw != "" {
+		offset, limit, err := cursor.Decode(raw)
+		if err != nil {
+			return types.Page{}, apierrors.InvalidQueryParam(cursorParam, err)
+		}
+		page.Offset = offset
+		page.Limit = limit
+	}
+
+	if raw := params.Get(limitParam); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return types.Page{}, apierrors.InvalidQueryParam(limitParam, fmt.Errorf("must be between 1 and %d", maxLimit))
+		}
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+func parseSort(raw string) (types.SortField, error) {
+	field, direction, _ := strings.Cut(strings.TrimSpace(raw), ":")
+
+	if _, ok := weaponfields.Lookup(field); !ok {
+		return types.SortField{}, fmt.Errorf("unknown field %q", field)
+	}
+
+	switch strings.ToLower(direction) {
+	case "", "asc":
+		return types.SortField{Field: field}, nil
+	case "desc":
+		return types.SortField{Field: field, Desc: true}, nil
+	default:
+		return types.SortField{}, fmt.Errorf("unknown sort direction %q", direction)
+	}
+}
+
+func parseFilter(raw string) (types.Filter, error) {
+	for _, op := range operators {
+		field, value, ok := strings.Cut(raw, op)
+		if !ok {
+			continue
+		}
+
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+
+		registered, ok := weaponfields.Lookup(field)
+		if !ok {
+			return types.Filter{}, fmt.Errorf("unknown field %q", field)
+		}
+
+		if op != types.OpEq && op != types.OpNe {
+			if registered.Text {
+				return types.Filter{}, fmt.Errorf("operator %s is not supported for text field %q", op, field)
+			}
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return types.Filter{}, fmt.Errorf("operator %s requires a number, got %q", op, value)
+			}
+		}
+
+		return types.Filter{Field: field, Operator: op, Value: value}, nil
+	}
+
+	return types.Filter{}, fmt.Errorf("missing operator in %q", raw)
+}
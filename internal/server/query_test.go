@@ -0,0 +1,184 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/lib/cursor"
+	"github.com/erknas/wt-guided-weapons/internal/lib/units"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWeaponsQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawQuery    string
+		want        types.WeaponsQuery
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			name:     "empty",
+			rawQuery: "",
+			want:     types.WeaponsQuery{},
+		},
+		{
+			name:     "sort filter and field equality",
+			rawQuery: "sort=maximum_g_load:desc,name&filter=guidance_range>10&filter=mass<=90&irccm=Yes",
+			want: types.WeaponsQuery{
+				Filters: []types.Filter{
+					{Field: "guidance_range", Operator: types.OpGt, Value: "10"},
+					{Field: "mass", Operator: types.OpLte, Value: "90"},
+					{Field: "irccm", Operator: types.OpEq, Value: "Yes"},
+				},
+				Sort: []types.SortField{
+					{Field: "maximum_g_load", Desc: true},
+					{Field: "name"},
+				},
+			},
+		},
+		{
+			name:     "not equal filter",
+			rawQuery: "filter=band!=J",
+			want: types.WeaponsQuery{
+				Filters: []types.Filter{{Field: "band", Operator: types.OpNe, Value: "J"}},
+			},
+		},
+		{
+			name:     "guidance and warhead",
+			rawQuery: "guidance=ARH,SARH&warhead=HE&warhead=HEAT&category=aam-arh",
+			want: types.WeaponsQuery{
+				Guidance: []string{"ARH", "SARH"},
+				Warheads: []string{"HE", "HEAT"},
+			},
+		},
+		{
+			name:     "limit cursor and fields",
+			rawQuery: "limit=20&cursor=" + cursor.Encode(40, 50) + "&fields=name,mass,id",
+			want: types.WeaponsQuery{
+				Fields: []string{"name", "mass"},
+				Page:   types.Page{Limit: 20, Offset: 40},
+			},
+		},
+		{
+			name:     "include retired",
+			rawQuery: "include_retired=true",
+			want:     types.WeaponsQuery{IncludeRetired: true},
+		},
+		{
+			name:     "cursor without limit",
+			rawQuery: "cursor=" + cursor.Encode(50, 20),
+			want: types.WeaponsQuery{
+				Page: types.Page{Limit: 20, Offset: 50},
+			},
+		},
+		{
+			name:        "invalid include retired",
+			rawQuery:    "include_retired=maybe",
+			wantErr:     true,
+			containsErr: retiredParam,
+		},
+		{
+			name:        "limit out of range",
+			rawQuery:    "limit=501",
+			wantErr:     true,
+			containsErr: "must be between",
+		},
+		{
+			name:        "invalid cursor",
+			rawQuery:    "cursor=@@",
+			wantErr:     true,
+			containsErr: "invalid cursor",
+		},
+		{
+			name:        "unknown projection field",
+			rawQuery:    "fields=speed",
+			wantErr:     true,
+			containsErr: "unknown field",
+		},
+		{
+			name:        "unknown sort field",
+			rawQuery:    "sort=speed",
+			wantErr:     true,
+			containsErr: "unknown field",
+		},
+		{
+			name:        "unknown sort direction",
+			rawQuery:    "sort=mass:up",
+			wantErr:     true,
+			containsErr: "unknown sort direction",
+		},
+		{
+			name:        "unknown filter field",
+			rawQuery:    "filter=speed>10",
+			wantErr:     true,
+			containsErr: "unknown field",
+		},
+		{
+			name:        "non numeric comparison",
+			rawQuery:    "filter=mass>heavy",
+			wantErr:     true,
+			containsErr: "requires a number",
+		},
+		{
+			name:        "comparison on text field",
+			rawQuery:    "filter=name>9",
+			wantErr:     true,
+			containsErr: "not supported for text field",
+		},
+		{
+			name:        "missing operator",
+			rawQuery:    "filter=mass",
+			wantErr:     true,
+			containsErr: "missing operator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.rawQuery)
+			require.NoError(t, err)
+
+			res, err := parseWeaponsQuery(params)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, res)
+			}
+		})
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		rawQuery string
+		want     string
+		wantErr  bool
+	}{
+		{rawQuery: "", want: units.Metric},
+		{rawQuery: "units=imperial", want: units.Imperial},
+		{rawQuery: "units=RAW", want: units.Raw},
+		{rawQuery: "units=nautical", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rawQuery, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.rawQuery)
+			require.NoError(t, err)
+
+			res, err := parseUnits(params)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, res)
+			}
+		})
+	}
+}
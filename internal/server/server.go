@@ -16,19 +16,32 @@ import (
 	"go.uber.org/zap"
 )
 
+const versionKey = "version"
+
 type WeaponsServicer interface {
-	UpdateWeapons(ctx context.Context) error
-	GetWeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error)
-	SearchWeapons(ctx context.Context, query string) ([]types.SearchResult, error)
+	UpdateWeapons(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error)
+	GetWeapons(ctx context.Context, query types.WeaponsQuery) (types.Weapons, error)
+	GetWeaponByID(ctx context.Context, id string) (*types.Weapon, error)
+	SearchWeapons(ctx context.Context, query string, page types.Page) (types.SearchResults, error)
+	SuggestWeapons(ctx context.Context, query string, limit int) types.Suggestions
+	GetWeaponHistory(ctx context.Context, id string) (types.WeaponHistory, error)
+	DiffVersions(ctx context.Context, from, to, category string) (types.VersionDiff, error)
+	CompareWeapons(ctx context.Context, ids []string) (types.Comparison, error)
+	GetSchemaReport(ctx context.Context, version string) (types.SchemaReport, error)
 }
 
 type VersionServicer interface {
 	GetVersion(ctx context.Context) (types.LastChange, error)
 }
 
+type CategoriesServicer interface {
+	GetCategories(ctx context.Context) (types.Categories, error)
+}
+
 type Server struct {
 	weapons    WeaponsServicer
 	version    VersionServicer
+	catalogue  CategoriesServicer
 	categories map[string]struct{}
 	log        *zap.Logger
 }
@@ -36,18 +49,23 @@ type Server struct {
 func New(
 	weapons WeaponsServicer,
 	version VersionServicer,
+	catalogue CategoriesServicer,
 	urls map[string]string,
 	log *zap.Logger,
 ) *Server {
 	categories := make(map[string]struct{}, len(urls))
 
 	for category := range urls {
+		if category == versionKey {
+			continue
+		}
 		categories[category] = struct{}{}
 	}
 
 	return &Server{
 		weapons:    weapons,
 		version:    version,
+		catalogue:  catalogue,
 		categories: categories,
 		log:        log,
 	}
@@ -104,8 +122,16 @@ func (s *Server) routes(r *chi.Mux) {
 
 	r.Route("/api", func(r chi.Router) {
 		r.Put("/update", api.MakeHTTPFunc(s.handleUpdateWeapons))
+		r.Get("/weapons", api.MakeHTTPFunc(s.handleGetWeapons))
 		r.With(logger.MiddlewareCategoryCheck(s.categories)).Get("/weapons/{category}", api.MakeHTTPFunc(s.handleGetWeaponsByCategory))
 		r.Get("/weapons/search/{name}", api.MakeHTTPFunc(s.handleSeachWeapons))
+		r.Get("/weapons/suggest", api.MakeHTTPFunc(s.handleSuggestWeapons))
+		r.Get("/weapons/id/{id}", api.MakeHTTPFunc(s.handleGetWeaponByID))
+		r.Get("/weapons/{id}/history", api.MakeHTTPFunc(s.handleGetWeaponHistory))
 		r.Get("/version", api.MakeHTTPFunc(s.handleGetVersion))
+		r.Get("/categories", api.MakeHTTPFunc(s.handleGetCategories))
+		r.Get("/diff", api.MakeHTTPFunc(s.handleDiffVersions))
+		r.Get("/compare", api.MakeHTTPFunc(s.handleCompareWeapons))
+		r.Get("/admin/schema-report", api.MakeHTTPFunc(s.handleGetSchemaReport))
 	})
 }
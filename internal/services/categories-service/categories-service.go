@@ -0,0 +1,92 @@
+package categoriesservice
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/erknas/wt-guided-weapons/internal/logger"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"go.uber.org/zap"
+)
+
+const versionKey = "version"
+
+var families = map[string]string{
+	"aam":  "AAM",
+	"agm":  "AGM",
+	"gbu":  "GBU",
+	"sam":  "SAM",
+	"atgm": "ATGM",
+	"ashm": "AShM",
+}
+
+type StatsProvider interface {
+	CategoryCounts(ctx context.Context) (map[string]int, error)
+	CategoryVersions(ctx context.Context) (map[string]string, error)
+}
+
+type CategoriesService struct {
+	tables   types.Tables
+	provider StatsProvider
+}
+
+func New(tables types.Tables, provider StatsProvider) *CategoriesService {
+	return &CategoriesService{
+		tables:   tables,
+		provider: provider,
+	}
+}
+
+func (s *CategoriesService) GetCategories(ctx context.Context) (types.Categories, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	counts, err := s.provider.CategoryCounts(ctx)
+	if err != nil {
+		log.Error("CategoryCounts error",
+			zap.Error(err),
+		)
+		return types.Categories{}, err
+	}
+
+	versions, err := s.provider.CategoryVersions(ctx)
+	if err != nil {
+		log.Error("CategoryVersions error",
+			zap.Error(err),
+		)
+		return types.Categories{}, err
+	}
+
+	categories := make([]types.Category, 0, len(s.tables))
+
+	for name, table := range s.tables {
+		if name == versionKey {
+			continue
+		}
+
+		family := table.Family
+		if family == "" {
+			prefix, _, _ := strings.Cut(name, "-")
+			family = families[prefix]
+		}
+
+		categories = append(categories, types.Category{
+			Name:        name,
+			Title:       table.Title,
+			Family:      family,
+			Guidance:    table.Guidance,
+			Count:       counts[name],
+			LastUpdated: versions[name],
+		})
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	log.Debug("GetCategories complited",
+		zap.Int("total categories", len(categories)),
+	)
+
+	return types.Categories{Categories: categories}, nil
+}
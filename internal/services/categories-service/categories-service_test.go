@@ -0,0 +1,92 @@
+package categoriesservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStatsProvider struct {
+	mock.Mock
+}
+
+func (m *mockStatsProvider) CategoryCounts(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *mockStatsProvider) CategoryVersions(ctx context.Context) (map[string]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func TestCategoriesService_GetCategories(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-arh":  {URL: "aam-url", Title: "Active radar AAM", Family: "AAM", Guidance: "ARH"},
+		"ashm-arh": {URL: "ashm-url"},
+	}
+
+	tests := []struct {
+		name        string
+		mocks       func(*mockStatsProvider)
+		want        types.Categories
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			name: "success",
+			mocks: func(msp *mockStatsProvider) {
+				msp.On("CategoryCounts", mock.Anything).Return(map[string]int{"aam-arh": 12}, nil)
+				msp.On("CategoryVersions", mock.Anything).Return(map[string]string{"aam-arh": "2.47.0.1"}, nil)
+			},
+			want: types.Categories{Categories: []types.Category{
+				{Name: "aam-arh", Title: "Active radar AAM", Family: "AAM", Guidance: "ARH", Count: 12, LastUpdated: "2.47.0.1"},
+				{Name: "ashm-arh", Family: "AShM"},
+			}},
+		},
+		{
+			name: "fail CategoryCounts error",
+			mocks: func(msp *mockStatsProvider) {
+				msp.On("CategoryCounts", mock.Anything).Return(map[string]int(nil), errors.New("failed to aggregate documents"))
+			},
+			wantErr:     true,
+			containsErr: "failed to aggregate documents",
+		},
+		{
+			name: "fail CategoryVersions error",
+			mocks: func(msp *mockStatsProvider) {
+				msp.On("CategoryCounts", mock.Anything).Return(map[string]int{}, nil)
+				msp.On("CategoryVersions", mock.Anything).Return(map[string]string(nil), errors.New("failed to aggregate documents"))
+			},
+			wantErr:     true,
+			containsErr: "failed to aggregate documents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := new(mockStatsProvider)
+			tt.mocks(provider)
+
+			service := New(tables, provider)
+
+			res, err := service.GetCategories(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, res)
+			}
+
+			provider.AssertExpectations(t)
+		})
+	}
+}
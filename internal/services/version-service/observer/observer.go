@@ -7,12 +7,18 @@ import (
 	"sync"
 	"time"
 
+	"github.com/erknas/wt-guided-weapons/internal/config"
 	"github.com/erknas/wt-guided-weapons/internal/storage/mongodb"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"go.uber.org/zap"
 )
 
-const period = time.Minute * 30
+const (
+	defaultPeriod  = time.Minute * 30
+	defaultTimeout = time.Minute * 5
+	checkTimeout   = time.Second * 10
+	versionKey     = "version"
+)
 
 type version struct {
 	version string
@@ -28,63 +34,131 @@ type VersionParser interface {
 }
 
 type WeaponsUpdater interface {
-	UpdateWeapons(ctx context.Context) error
+	UpdateWeapons(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error)
+}
+
+type ContentChecker interface {
+	Changed(ctx context.Context, categories []string) (bool, error)
+	Seed(hashes map[string]string)
 }
 
 type ChangeObserver struct {
 	provider VersionProvider
 	parser   VersionParser
 	updater  WeaponsUpdater
+	checker  ContentChecker
 	log      *zap.Logger
-	url      string
+	tables   types.Tables
+	period   time.Duration
+	timeout  time.Duration
+	partial  bool
 }
 
 func New(
 	provider VersionProvider,
 	parser VersionParser,
 	updater WeaponsUpdater,
+	checker ContentChecker,
 	log *zap.Logger,
-	url string,
+	tables types.Tables,
+	cfg config.ConfigUpdate,
 ) *ChangeObserver {
+	period := cfg.Period
+	if period <= 0 {
+		period = defaultPeriod
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
 	return &ChangeObserver{
 		provider: provider,
 		parser:   parser,
 		updater:  updater,
+		checker:  checker,
 		log:      log,
-		url:      url,
+		tables:   tables,
+		period:   period,
+		timeout:  timeout,
+		partial:  cfg.Partial,
 	}
 }
 
 func (o *ChangeObserver) Observe(ctx context.Context) {
-	_, err := o.provider.GetVersion(ctx)
-	if err != nil && errors.Is(err, mongodb.ErrNoVersion) {
+	last, err := o.provider.GetVersion(ctx)
+	switch {
+	case errors.Is(err, mongodb.ErrNoVersion):
 		o.log.Info("Inserting initial data")
-		err := o.updater.UpdateWeapons(ctx)
+		_, err := o.update(ctx, types.UpdateRequest{Partial: o.partial, Trigger: types.TriggerStartup})
 		if err != nil {
 			o.log.Error("Failed to insert initial data",
 				zap.Error(err),
 			)
 		}
+	case err == nil:
+		o.checker.Seed(last.SourceHashes)
 	}
 
+	wg := &sync.WaitGroup{}
+
+	for category, table := range o.tables {
+		period := o.period
+		if table.Refresh > 0 {
+			period = table.Refresh
+		}
+
+		check := func(ctx context.Context) error {
+			return o.checkCategoryChange(ctx, category)
+		}
+		if category == versionKey {
+			check = o.checkVersionChange
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.schedule(ctx, category, period, check)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (o *ChangeObserver) schedule(ctx context.Context, category string, period time.Duration, check func(context.Context) error) {
+	o.log.Debug("scheduling",
+		zap.String("category", category),
+		zap.Duration("period", period),
+	)
+
 	ticker := time.NewTicker(period)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			if err := o.checkVersionChange(ctx); err != nil {
-				o.log.Error("checkVersionChange error",
+			if err := check(ctx); err != nil {
+				o.log.Error("check error",
+					zap.String("category", category),
 					zap.Error(err),
 				)
 			}
 		case <-ctx.Done():
+			return
 		}
 	}
 }
 
+func (o *ChangeObserver) update(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	return o.updater.UpdateWeapons(ctx, req)
+}
+
 func (o *ChangeObserver) checkVersionChange(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
 	defer cancel()
 
 	var currVersion, newVerison version
@@ -95,14 +169,14 @@ func (o *ChangeObserver) checkVersionChange(ctx context.Context) error {
 	go func() {
 		defer wg.Done()
 
-		ver, err := o.provider.GetVersion(ctx)
-		currVersion = version{version: ver.Version.Version, err: err}
+		ver, err := o.provider.GetVersion(checkCtx)
+		currVersion = version{version: ver.Version, err: err}
 	}()
 
 	go func() {
 		defer wg.Done()
 
-		ver, err := o.parser.Parse(ctx, o.url)
+		ver, err := o.parser.Parse(checkCtx, o.tables[versionKey].URL)
 		newVerison = version{version: ver.Version, err: err}
 	}()
 
@@ -127,7 +201,8 @@ func (o *ChangeObserver) checkVersionChange(ctx context.Context) error {
 	)
 
 	if currVersion.version != newVerison.version {
-		if err := o.updater.UpdateWeapons(ctx); err != nil {
+		result, err := o.update(ctx, types.UpdateRequest{Partial: o.partial, Trigger: types.TriggerVersion})
+		if err != nil {
 			o.log.Error("UpdateWeapons error",
 				zap.Error(err),
 			)
@@ -135,10 +210,49 @@ func (o *ChangeObserver) checkVersionChange(ctx context.Context) error {
 		}
 		o.log.Info("Weapons updated",
 			zap.String("version changed", fmt.Sprintf("%s -> %s", currVersion.version, newVerison.version)),
+			zap.Int("retries", result.Retries),
 		)
+		return nil
 	}
 
 	o.log.Info("Nothing to update")
 
 	return nil
 }
+
+func (o *ChangeObserver) checkCategoryChange(ctx context.Context, category string) error {
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	changed, err := o.checker.Changed(checkCtx, []string{category})
+	if err != nil {
+		o.log.Error("Changed error",
+			zap.String("category", category),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to check table content: %w", err)
+	}
+
+	if !changed {
+		o.log.Debug("Nothing to update",
+			zap.String("category", category),
+		)
+		return nil
+	}
+
+	result, err := o.update(ctx, types.UpdateRequest{Partial: o.partial, Categories: []string{category}, Trigger: types.TriggerContent})
+	if err != nil {
+		o.log.Error("UpdateWeapons error",
+			zap.String("category", category),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to update weapons")
+	}
+
+	o.log.Info("Weapons updated",
+		zap.String("content changed", category),
+		zap.Int("retries", result.Retries),
+	)
+
+	return nil
+}
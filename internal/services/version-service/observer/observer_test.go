@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/erknas/wt-guided-weapons/internal/config"
+	"github.com/erknas/wt-guided-weapons/internal/storage/mongodb"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +27,10 @@ type mockWeaponsUpdater struct {
 	mock.Mock
 }
 
+type mockContentChecker struct {
+	mock.Mock
+}
+
 func (m *mockVersionProvider) GetVersion(ctx context.Context) (types.LastChange, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(types.LastChange), args.Error(1)
@@ -34,39 +41,100 @@ func (m *mockVersionParser) Parse(ctx context.Context, url string) (types.Versio
 	return args.Get(0).(types.VersionInfo), args.Error(1)
 }
 
-func (m *mockWeaponsUpdater) UpdateWeapons(ctx context.Context) error {
-	agrs := m.Called(ctx)
-	return agrs.Error(0)
+func (m *mockWeaponsUpdater) UpdateWeapons(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error) {
+	agrs := m.Called(ctx, req)
+	return agrs.Get(0).(types.UpdateResult), agrs.Error(1)
+}
+
+func (m *mockContentChecker) Changed(ctx context.Context, categories []string) (bool, error) {
+	args := m.Called(ctx, categories)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockContentChecker) Seed(hashes map[string]string) {
+	m.Called(hashes)
+}
+
+var updateCtx = mock.MatchedBy(func(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) > checkTimeout
+})
+
+func TestObserver_Observe(t *testing.T) {
+	hashes := map[string]string{"aam-arh": "abc"}
+
+	tests := []struct {
+		name  string
+		mocks func(mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker)
+	}{
+		{
+			name: "seed from last ingest",
+			mocks: func(mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.Anything).Return(types.LastChange{SourceHashes: hashes}, nil)
+				mcc.On("Seed", hashes)
+			},
+		},
+		{
+			name: "initial data",
+			mocks: func(mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.Anything).Return(types.LastChange{}, mongodb.ErrNoVersion)
+				mwu.On("UpdateWeapons", updateCtx, types.UpdateRequest{Trigger: types.TriggerStartup}).Return(types.UpdateResult{}, nil)
+			},
+		},
+		{
+			name: "GetVersion error",
+			mocks: func(mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.Anything).Return(types.LastChange{}, errors.New("failed to find document"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mvpr := new(mockVersionProvider)
+			mwu := new(mockWeaponsUpdater)
+			mcc := new(mockContentChecker)
+
+			tt.mocks(mvpr, mwu, mcc)
+
+			observer := New(mvpr, new(mockVersionParser), mwu, mcc, zap.NewNop(), types.Tables{}, config.ConfigUpdate{})
+			observer.Observe(context.Background())
+
+			mvpr.AssertExpectations(t)
+			mwu.AssertExpectations(t)
+			mcc.AssertExpectations(t)
+		})
+	}
 }
 
 func TestObserver_checkVersionChange(t *testing.T) {
 	tests := []struct {
 		name        string
-		mocks       func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater)
+		mocks       func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker)
 		wantErr     bool
 		containsErr string
 	}{
 		{
 			name: "success",
-			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater) {
-				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{Version: types.VersionInfo{Version: "2.47"}}, nil)
+			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{VersionInfo: types.VersionInfo{Version: "2.47"}}, nil)
 				mvpa.On("Parse", mock.AnythingOfType("*context.timerCtx"), "test-url").Return(types.VersionInfo{Version: "2.49"}, nil)
-				mwu.On("UpdateWeapons", mock.AnythingOfType("*context.timerCtx")).Return(nil)
+				mwu.On("UpdateWeapons", updateCtx, types.UpdateRequest{Trigger: types.TriggerVersion}).Return(types.UpdateResult{}, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "same version",
-			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater) {
-				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{Version: types.VersionInfo{Version: "2.47"}}, nil)
+			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{VersionInfo: types.VersionInfo{Version: "2.47"}}, nil)
 				mvpa.On("Parse", mock.AnythingOfType("*context.timerCtx"), "test-url").Return(types.VersionInfo{Version: "2.47"}, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "failed Parse error",
-			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater) {
-				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{Version: types.VersionInfo{Version: "2.47"}}, nil)
+			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{VersionInfo: types.VersionInfo{Version: "2.47"}}, nil)
 				mvpa.On("Parse", mock.AnythingOfType("*context.timerCtx"), "test-url").Return(types.VersionInfo{}, errors.New("failed to read CSV"))
 			},
 			wantErr:     true,
@@ -74,10 +142,10 @@ func TestObserver_checkVersionChange(t *testing.T) {
 		},
 		{
 			name: "failed UpdateWeapons error",
-			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater) {
-				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{Version: types.VersionInfo{Version: "2.47"}}, nil)
+			mocks: func(mvpa *mockVersionParser, mvpr *mockVersionProvider, mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mvpr.On("GetVersion", mock.AnythingOfType("*context.timerCtx")).Return(types.LastChange{VersionInfo: types.VersionInfo{Version: "2.47"}}, nil)
 				mvpa.On("Parse", mock.AnythingOfType("*context.timerCtx"), "test-url").Return(types.VersionInfo{Version: "2.49"}, nil)
-				mwu.On("UpdateWeapons", mock.AnythingOfType("*context.timerCtx")).Return(errors.New("failed to aggregate weapons"))
+				mwu.On("UpdateWeapons", updateCtx, types.UpdateRequest{Trigger: types.TriggerVersion}).Return(types.UpdateResult{}, errors.New("failed to aggregate weapons"))
 			},
 			wantErr:     true,
 			containsErr: "failed to update weapons",
@@ -89,10 +157,11 @@ func TestObserver_checkVersionChange(t *testing.T) {
 			mvpr := new(mockVersionProvider)
 			mvpa := new(mockVersionParser)
 			mwu := new(mockWeaponsUpdater)
+			mcc := new(mockContentChecker)
 
-			tt.mocks(mvpa, mvpr, mwu)
+			tt.mocks(mvpa, mvpr, mwu, mcc)
 
-			observer := New(mvpr, mvpa, mwu, zap.NewNop(), "test-url")
+			observer := New(mvpr, mvpa, mwu, mcc, zap.NewNop(), types.Tables{"version": {URL: "test-url"}}, config.ConfigUpdate{})
 
 			ctx := context.Background()
 			err := observer.checkVersionChange(ctx)
@@ -109,9 +178,86 @@ func TestObserver_checkVersionChange(t *testing.T) {
 			mvpr.AssertExpectations(t)
 			mvpa.AssertExpectations(t)
 			mwu.AssertExpectations(t)
+			mcc.AssertExpectations(t)
 
 			if tt.name == "same version" {
 				mwu.AssertNotCalled(t, "UpdateWeapons")
+				mcc.AssertNotCalled(t, "Changed")
+			}
+		})
+	}
+}
+
+func TestObserver_checkCategoryChange(t *testing.T) {
+	category := "aam-arh"
+	req := types.UpdateRequest{Partial: true, Categories: []string{category}, Trigger: types.TriggerContent}
+
+	tests := []struct {
+		name        string
+		mocks       func(mwu *mockWeaponsUpdater, mcc *mockContentChecker)
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			name: "content changed",
+			mocks: func(mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mcc.On("Changed", mock.AnythingOfType("*context.timerCtx"), []string{category}).Return(true, nil)
+				mwu.On("UpdateWeapons", updateCtx, req).Return(types.UpdateResult{}, nil)
+			},
+		},
+		{
+			name: "content unchanged",
+			mocks: func(mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mcc.On("Changed", mock.AnythingOfType("*context.timerCtx"), []string{category}).Return(false, nil)
+			},
+		},
+		{
+			name: "failed Changed error",
+			mocks: func(mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mcc.On("Changed", mock.AnythingOfType("*context.timerCtx"), []string{category}).Return(false, errors.New("failed to read CSV"))
+			},
+			wantErr:     true,
+			containsErr: "failed to check table content",
+		},
+		{
+			name: "failed UpdateWeapons error",
+			mocks: func(mwu *mockWeaponsUpdater, mcc *mockContentChecker) {
+				mcc.On("Changed", mock.AnythingOfType("*context.timerCtx"), []string{category}).Return(true, nil)
+				mwu.On("UpdateWeapons", updateCtx, req).Return(types.UpdateResult{}, errors.New("failed to aggregate weapons"))
+			},
+			wantErr:     true,
+			containsErr: "failed to update weapons",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mwu := new(mockWeaponsUpdater)
+			mcc := new(mockContentChecker)
+
+			tt.mocks(mwu, mcc)
+
+			tables := types.Tables{
+				"version": {URL: "test-url"},
+				category:  {URL: "aam-arh-url", Refresh: time.Minute * 10},
+			}
+
+			observer := New(new(mockVersionProvider), new(mockVersionParser), mwu, mcc, zap.NewNop(), tables, config.ConfigUpdate{Partial: true})
+
+			err := observer.checkCategoryChange(context.Background(), category)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mwu.AssertExpectations(t)
+			mcc.AssertExpectations(t)
+
+			if tt.name == "content unchanged" {
+				mwu.AssertNotCalled(t, "UpdateWeapons")
 			}
 		})
 	}
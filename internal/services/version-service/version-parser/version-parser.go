@@ -2,6 +2,7 @@ package versionparser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,10 @@ import (
 	"github.com/erknas/wt-guided-weapons/internal/types"
 )
 
+const versionRow = 3
+
+var ErrEmptyTable = errors.New("empty table")
+
 type CSVVersionParser struct {
 	reader csvreader.Reader
 }
@@ -25,7 +30,14 @@ func (p *CSVVersionParser) Parse(ctx context.Context, url string) (types.Version
 		return types.VersionInfo{}, fmt.Errorf("failed to read CSV: %w", err)
 	}
 
-	fields := strings.Fields(data[3][0])
+	if len(data) <= versionRow || len(data[versionRow]) == 0 {
+		return types.VersionInfo{}, fmt.Errorf("%w: %s", ErrEmptyTable, url)
+	}
+
+	fields := strings.Fields(data[versionRow][0])
+	if len(fields) == 0 {
+		return types.VersionInfo{}, fmt.Errorf("%w: %s", ErrEmptyTable, url)
+	}
 
 	return types.VersionInfo{Version: fields[len(fields)-1]}, nil
 }
@@ -29,6 +29,7 @@ func TestParse(t *testing.T) {
 		ctx         func() context.Context
 		wantErr     bool
 		containsErr string
+		isErr       error
 	}{
 		{
 			name: "success",
@@ -61,6 +62,33 @@ func TestParse(t *testing.T) {
 			wantErr:     true,
 			containsErr: "failed to read CSV",
 		},
+		{
+			name: "fail empty table",
+			mock: func(mr *mockReader) {
+				mr.On("Read", mock.Anything, "test-url").Return([][]string{}, nil)
+			},
+			wantErr:     true,
+			containsErr: "empty table",
+			isErr:       ErrEmptyTable,
+		},
+		{
+			name: "fail short table",
+			mock: func(mr *mockReader) {
+				mr.On("Read", mock.Anything, "test-url").Return(testData[:3], nil)
+			},
+			wantErr:     true,
+			containsErr: "empty table",
+			isErr:       ErrEmptyTable,
+		},
+		{
+			name: "fail blank version row",
+			mock: func(mr *mockReader) {
+				mr.On("Read", mock.Anything, "test-url").Return([][]string{{"asd"}, {""}, {"123"}, {"  "}}, nil)
+			},
+			wantErr:     true,
+			containsErr: "empty table",
+			isErr:       ErrEmptyTable,
+		},
 		{
 			name: "fail Read context timeout",
 			mock: func(mr *mockReader) {
@@ -95,6 +123,9 @@ func TestParse(t *testing.T) {
 				require.Error(t, err)
 				assert.Empty(t, results)
 				assert.Contains(t, err.Error(), tt.containsErr)
+				if tt.isErr != nil {
+					assert.ErrorIs(t, err, tt.isErr)
+				}
 			} else {
 				require.NoError(t, err)
 				assert.NotEmpty(t, results)
@@ -11,10 +11,6 @@ import (
 	"go.uber.org/zap"
 )
 
-type VersionUpserter interface {
-	UpsertVersion(ctx context.Context, version types.VersionInfo) error
-}
-
 type VersionProvider interface {
 	Version(ctx context.Context) (types.LastChange, error)
 }
@@ -24,27 +20,24 @@ type VersionParser interface {
 }
 
 type VersionService struct {
-	upserter VersionUpserter
 	provider VersionProvider
 	parser   VersionParser
 	url      string
 }
 
 func New(
-	upserter VersionUpserter,
 	provider VersionProvider,
 	parser VersionParser,
 	url string,
 ) *VersionService {
 	return &VersionService{
-		upserter: upserter,
 		parser:   parser,
 		provider: provider,
 		url:      url,
 	}
 }
 
-func (s *VersionService) UpdateVersion(ctx context.Context) error {
+func (s *VersionService) LatestVersion(ctx context.Context) (types.VersionInfo, error) {
 	log := logger.FromContext(ctx, logger.Service)
 
 	version, err := s.parser.Parse(ctx, s.url)
@@ -52,21 +45,10 @@ func (s *VersionService) UpdateVersion(ctx context.Context) error {
 		log.Error("Parse error",
 			zap.Error(err),
 		)
-		return fmt.Errorf("failed to parse version: %w", err)
+		return types.VersionInfo{}, fmt.Errorf("failed to parse version: %w", err)
 	}
 
-	if err := s.upserter.UpsertVersion(ctx, version); err != nil {
-		log.Error("UpsertVersion error",
-			zap.Error(err),
-		)
-		return fmt.Errorf("failed to update version: %w", err)
-	}
-
-	log.Debug("UpdateVersion complited",
-		zap.String("new version", version.Version),
-	)
-
-	return nil
+	return version, nil
 }
 
 func (s *VersionService) GetVersion(ctx context.Context) (types.LastChange, error) {
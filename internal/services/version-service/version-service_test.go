@@ -11,10 +11,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-type mockVersionUpserter struct {
-	mock.Mock
-}
-
 type mockVersionProvider struct {
 	mock.Mock
 }
@@ -23,11 +19,6 @@ type mockVersionParser struct {
 	mock.Mock
 }
 
-func (m *mockVersionUpserter) UpsertVersion(ctx context.Context, version types.VersionInfo) error {
-	args := m.Called(ctx, version)
-	return args.Error(0)
-}
-
 func (m *mockVersionProvider) Version(ctx context.Context) (types.LastChange, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(types.LastChange), args.Error(1)
@@ -38,70 +29,60 @@ func (m *mockVersionParser) Parse(ctx context.Context, url string) (types.Versio
 	return args.Get(0).(types.VersionInfo), args.Error(1)
 }
 
-func TestVersionService_UpdateVersion(t *testing.T) {
+func TestVersionService_LatestVersion(t *testing.T) {
 	version := types.VersionInfo{Version: "2.47.0.114"}
 
 	test := []struct {
 		name        string
-		mocks       func(*mockVersionParser, *mockVersionUpserter)
+		mocks       func(*mockVersionParser)
 		wantErr     bool
 		containsErr string
 	}{
 		{
 			name: "success",
-			mocks: func(mvp *mockVersionParser, mvu *mockVersionUpserter) {
-				mvp.On("Parse", mock.Anything, mock.Anything).Return(version, nil)
-				mvu.On("UpsertVersion", mock.Anything, version).Return(nil)
+			mocks: func(mvp *mockVersionParser) {
+				mvp.On("Parse", mock.Anything, "test-url").Return(version, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "fail Parse error",
-			mocks: func(mvp *mockVersionParser, mvu *mockVersionUpserter) {
-				mvp.On("Parse", mock.Anything, mock.Anything).Return(types.VersionInfo{}, errors.New("failed to read CSV"))
+			mocks: func(mvp *mockVersionParser) {
+				mvp.On("Parse", mock.Anything, "test-url").Return(types.VersionInfo{}, errors.New("failed to read CSV"))
 			},
 			wantErr:     true,
 			containsErr: "failed to parse version",
 		},
-		{
-			name: "fail UpsertVersion error",
-			mocks: func(mvp *mockVersionParser, mvu *mockVersionUpserter) {
-				mvp.On("Parse", mock.Anything, mock.Anything).Return(version, nil)
-				mvu.On("UpsertVersion", mock.Anything, version).Return(errors.New("failed to update document"))
-			},
-			wantErr:     true,
-			containsErr: "failed to update version",
-		},
 	}
 
 	for _, tt := range test {
 		t.Run(tt.name, func(t *testing.T) {
 			mvp := new(mockVersionParser)
-			mvu := new(mockVersionUpserter)
-			tt.mocks(mvp, mvu)
+			tt.mocks(mvp)
 
-			service := New(mvu, nil, mvp, "test-url")
+			service := New(nil, mvp, "test-url")
 
 			ctx := context.Background()
 
-			err := service.UpdateVersion(ctx)
+			res, err := service.LatestVersion(ctx)
 
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.containsErr)
+				assert.Empty(t, res)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, version, res)
 			}
 
 			mvp.AssertExpectations(t)
-			mvu.AssertExpectations(t)
 		})
 	}
 }
 
 func TestVersionService_GetVersion(t *testing.T) {
 	version := types.VersionInfo{Version: "2.47.0.114"}
-	lastChange := types.LastChange{Version: version}
+	lastChange := types.LastChange{VersionInfo: version, Trigger: types.TriggerVersion, Weapons: 120}
 
 	test := []struct {
 		name        string
@@ -139,7 +120,7 @@ func TestVersionService_GetVersion(t *testing.T) {
 			mvp := new(mockVersionProvider)
 			tt.mocks(mvp)
 
-			service := New(&mockVersionUpserter{}, mvp, &mockVersionParser{}, "test-url")
+			service := New(mvp, &mockVersionParser{}, "test-url")
 
 			ctx := context.Background()
 
@@ -0,0 +1,130 @@
+package weaponcomparator
+
+import (
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+var lowerIsBetter = map[string]struct{}{
+	"mass":                              {},
+	"booster_start_delay":               {},
+	"guidance_start_delay":              {},
+	"seeker_warm_up_time":               {},
+	"min_angle_of_incidence_to_sun":     {},
+	"irccm_reaction_time":               {},
+	"min_target_size":                   {},
+	"minimum_range":                     {},
+	"minimum_range_km":                  {},
+	"proximity_fuse_arming_distance":    {},
+	"proximity_fuse_minimum_altitude":   {},
+	"flight_time_until_guidance_starts": {},
+	"flight_time_when_pull_limit_100":   {},
+	"skim_altitude":                     {},
+}
+
+var incomparable = map[string]struct{}{
+	"caliber":                          {},
+	"length":                           {},
+	"mass_at_end_of_booster_burn":      {},
+	"mass_at_end_of_sustainer_burn":    {},
+	"burn_time_of_booster":             {},
+	"burn_time_of_sustainer":           {},
+	"default_zoom":                     {},
+	"aim_tracking_sensitivity":         {},
+	"impact_fuse_sensitivity":          {},
+	"impact_fuse_delay":                {},
+	"proximity_fuse_delay":             {},
+	"proportional_nav_multiplier":      {},
+	"pid_proportional":                 {},
+	"pid_integral":                     {},
+	"pid_integral_limit":               {},
+	"pid_derivative":                   {},
+	"orienting_start_delay":            {},
+	"orienting_control_time":           {},
+	"orienting_elevation_addition":     {},
+	"drag_coefficient_multiplier":      {},
+	"drag_coefficient_multiplier_bomb": {},
+	"wing_area_multiplier":             {},
+	"loft_angle":                       {},
+	"target_elevation":                 {},
+	"attack_altitude":                  {},
+}
+
+func Compare(weapons []*types.Weapon) types.Comparison {
+	comparison := types.Comparison{
+		Weapons: make([]types.WeaponRef, 0, len(weapons)),
+		Fields:  []types.ComparedField{},
+	}
+
+	for _, weapon := range weapons {
+		comparison.Weapons = append(comparison.Weapons, types.WeaponRef{
+			ID:       weapon.ID,
+			Name:     weapon.Name,
+			Category: weapon.Category,
+		})
+	}
+
+	for _, field := range weaponfields.Fields() {
+		values := make([]string, 0, len(weapons))
+		populated := false
+
+		for _, weapon := range weapons {
+			value := field.Value(weapon)
+			if value != "" {
+				populated = true
+			}
+			values = append(values, value)
+		}
+
+		if !populated {
+			continue
+		}
+
+		comparison.Fields = append(comparison.Fields, types.ComparedField{
+			Field:  field.Key,
+			Unit:   field.Unit,
+			Values: values,
+			Best:   best(field.Key, weapons),
+		})
+	}
+
+	return comparison
+}
+
+func best(key string, weapons []*types.Weapon) []string {
+	if _, ok := incomparable[key]; ok {
+		return nil
+	}
+
+	_, lower := lowerIsBetter[key]
+
+	var (
+		ids     []string
+		top     float64
+		numeric int
+	)
+
+	for _, weapon := range weapons {
+		stat, ok := weapon.Stats[key]
+		if !ok || stat.Value == nil {
+			continue
+		}
+		numeric++
+
+		value := *stat.Value
+
+		switch {
+		case len(ids) == 0, lower && value < top, !lower && value > top:
+			top = value
+			ids = []string{weapon.ID}
+		case value == top:
+			ids = append(ids, weapon.ID)
+		}
+	}
+
+	if numeric < 2 || len(ids) == numeric {
+		return nil
+	}
+
+	return ids
+}
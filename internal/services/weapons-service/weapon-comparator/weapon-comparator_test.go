@@ -0,0 +1,86 @@
+package weaponcomparator
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stat(v float64) types.Stat {
+	return types.Stat{Value: &v}
+}
+
+func TestCompare(t *testing.T) {
+	weapons := []*types.Weapon{
+		{
+			ID: "1", Category: "aam-arh", Name: "R-77",
+			Mass: "175", MaximumGLoad: "40", Caliber: "200",
+			Stats: types.Stats{"mass": stat(175), "maximum_g_load": stat(40), "caliber": stat(200)},
+		},
+		{
+			ID: "2", Category: "sam-arh", Name: "9M96E",
+			Mass: "333", MaximumGLoad: "40", Caliber: "240", SeekerRange: "20",
+			Stats: types.Stats{"mass": stat(333), "maximum_g_load": stat(40), "caliber": stat(240), "seeker_range": stat(20)},
+		},
+		{
+			ID: "3", Category: "aam-arh", Name: "AIM-120A",
+			Mass: "152", MaximumGLoad: "30",
+			Stats: types.Stats{"mass": stat(152), "maximum_g_load": stat(30)},
+		},
+	}
+
+	res := Compare(weapons)
+
+	require.Len(t, res.Weapons, 3)
+	assert.Equal(t, types.WeaponRef{ID: "2", Category: "sam-arh", Name: "9M96E"}, res.Weapons[1])
+
+	fields := make(map[string]types.ComparedField, len(res.Fields))
+	for _, field := range res.Fields {
+		fields[field.Field] = field
+	}
+
+	assert.NotContains(t, fields, "warhead")
+	assert.NotContains(t, fields, "category")
+
+	tests := []struct {
+		name     string
+		field    string
+		wantBest []string
+	}{
+		{
+			name:     "lower is better",
+			field:    "mass",
+			wantBest: []string{"3"},
+		},
+		{
+			name:     "higher is better with tie",
+			field:    "maximum_g_load",
+			wantBest: []string{"1", "2"},
+		},
+		{
+			name:     "incomparable",
+			field:    "caliber",
+			wantBest: nil,
+		},
+		{
+			name:     "single numeric value",
+			field:    "seeker_range",
+			wantBest: nil,
+		},
+		{
+			name:     "text field",
+			field:    "name",
+			wantBest: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, fields, tt.field)
+			assert.Equal(t, tt.wantBest, fields[tt.field].Best)
+			assert.Len(t, fields[tt.field].Values, 3)
+		})
+	}
+}
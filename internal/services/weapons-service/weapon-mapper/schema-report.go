@@ -0,0 +1,92 @@
+package weaponmapper
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+func SchemaReport(version string, reports []types.CategoryReport, previous types.SchemaReport) types.SchemaReport {
+	prev := make(map[string]types.CategorySchema, len(previous.Categories))
+	for _, schema := range previous.Categories {
+		prev[schema.Category] = schema
+	}
+
+	categories := make(map[string]types.CategorySchema, len(prev))
+	for category, schema := range prev {
+		categories[category] = schema
+	}
+
+	for _, report := range reports {
+		if report.Status != types.CategoryStatusOK || report.Labels == nil {
+			continue
+		}
+		categories[report.Category] = categorySchema(report.Category, report.Labels, prev[report.Category].Labels)
+	}
+
+	seen := make(map[string]struct{})
+
+	result := types.SchemaReport{
+		Version:    version,
+		CreatedAt:  time.Now().UTC(),
+		Categories: make([]types.CategorySchema, 0, len(categories)),
+	}
+
+	for _, schema := range categories {
+		for _, label := range schema.Labels {
+			seen[label] = struct{}{}
+		}
+		result.Categories = append(result.Categories, schema)
+	}
+
+	slices.SortFunc(result.Categories, func(a, b types.CategorySchema) int {
+		return strings.Compare(a.Category, b.Category)
+	})
+
+	for _, field := range weaponfields.Fields() {
+		if len(field.Labels) == 0 {
+			continue
+		}
+		matched := slices.ContainsFunc(field.Labels, func(label string) bool {
+			_, ok := seen[label]
+			return ok
+		})
+		if !matched {
+			result.Unmatched = append(result.Unmatched, field.Labels[0])
+		}
+	}
+
+	return result
+}
+
+func categorySchema(category string, current, previous []string) types.CategorySchema {
+	schema := types.CategorySchema{
+		Category: category,
+		Labels:   current,
+		Rows:     len(current),
+	}
+
+	for _, label := range current {
+		if Known(label) {
+			schema.Known++
+		} else {
+			schema.Unknown = append(schema.Unknown, label)
+		}
+	}
+
+	for _, label := range previous {
+		if !slices.Contains(current, label) {
+			schema.Missing = append(schema.Missing, label)
+		}
+	}
+
+	if schema.Rows > 0 {
+		schema.Coverage = math.Round(float64(schema.Known)/float64(schema.Rows)*100) / 100
+	}
+
+	return schema
+}
@@ -0,0 +1,56 @@
+package weaponmapper
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnown(t *testing.T) {
+	assert.True(t, Known("Name:"))
+	assert.True(t, Known("IRCCM rejection treshold:"))
+	assert.True(t, Known("IRCCM rejection threshold:"))
+	assert.False(t, Known("IRCCM rejection limit:"))
+	assert.True(t, Known("Distance minimum signal gate:"))
+}
+
+func TestSchemaReport(t *testing.T) {
+	previous := types.SchemaReport{
+		Version: "2.45.0.1",
+		Categories: []types.CategorySchema{
+			{Category: "aam-ir-all-aspect", Labels: []string{"Name:", "IRCCM rejection treshold:"}},
+			{Category: "aam-arh", Labels: []string{"Name:", "Band:"}, Rows: 2, Known: 2, Coverage: 1},
+		},
+	}
+
+	reports := []types.CategoryReport{
+		{
+			Category: "aam-ir-all-aspect",
+			Status:   types.CategoryStatusOK,
+			Labels:   []string{"Name:", "IRCCM rejection limit:", "Mass: [kg]", "Seeker range: [km]"},
+		},
+		{Category: "aam-arh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+	}
+
+	report := SchemaReport("2.47.0.1", reports, previous)
+
+	assert.Equal(t, "2.47.0.1", report.Version)
+	require.Len(t, report.Categories, 2)
+
+	assert.Equal(t, previous.Categories[1], report.Categories[0])
+
+	ir := report.Categories[1]
+	assert.Equal(t, "aam-ir-all-aspect", ir.Category)
+	assert.Equal(t, 4, ir.Rows)
+	assert.Equal(t, 3, ir.Known)
+	assert.Equal(t, 0.75, ir.Coverage)
+	assert.Equal(t, []string{"IRCCM rejection limit:"}, ir.Unknown)
+	assert.Equal(t, []string{"IRCCM rejection treshold:"}, ir.Missing)
+
+	assert.Contains(t, report.Unmatched, "IRCCM rejection treshold:")
+	assert.NotContains(t, report.Unmatched, "Band:")
+	assert.NotContains(t, report.Unmatched, "Name:")
+	assert.NotContains(t, report.Unmatched, "IRCCM rejection threshold:")
+}
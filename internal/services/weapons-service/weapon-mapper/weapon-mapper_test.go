@@ -53,3 +53,52 @@ func TestMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestMapper_Labels(t *testing.T) {
+	data := [][]string{
+		{"Name:", "AIM-9M", "R-73"},
+		{" Mass: [kg] ", "85.5", "105"},
+		{"IRCCM rejection threshold:", "0.5"},
+		{"Distance minimum signal gate:", "100", "200"},
+		{"Unknown row:", "x", "y"},
+	}
+
+	mapper := &WeaponMapper{}
+
+	first, err := mapper.Map(data, "aam-ir-all-aspect", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "aam-ir-all-aspect", first.Category)
+	assert.Equal(t, "AIM-9M", first.Name)
+	assert.Equal(t, "85.5", first.Mass)
+	assert.Equal(t, "0.5", first.IRCCMRejectionThreshold)
+	assert.Equal(t, "100", first.DistanceMinSignalGate)
+	assert.Equal(t, "m", first.Quantities["distance_min_signal_gate"].Unit)
+	assert.Equal(t, map[string]string{"Unknown row:": "x"}, first.Extra)
+	assert.Equal(t, 1, first.Column)
+
+	second, err := mapper.Map(data, "aam-ir-all-aspect", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "R-73", second.Name)
+	assert.Empty(t, second.IRCCMRejectionThreshold)
+	assert.Equal(t, map[string]string{"Unknown row:": "y"}, second.Extra)
+}
+
+func TestMapper_Extra(t *testing.T) {
+	data := [][]string{
+		{"Name:", "AIM-120A"},
+		{"Datalink range: [km]", "40"},
+		{"Mach 1.5 drag: [N]", "1200"},
+		{"Empty row:", " "},
+		{"", "ignored"},
+	}
+
+	mapper := &WeaponMapper{}
+
+	weapon, err := mapper.Map(data, "aam-arh", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"Datalink range: [km]": "40",
+		"Mach 1_5 drag: [N]":   "1200",
+	}, weapon.Extra)
+}
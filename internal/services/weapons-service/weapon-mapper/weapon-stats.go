@@ -0,0 +1,79 @@
+package weaponmapper
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+var thousands = regexp.MustCompile(`^[-+]?\d{1,3}(,\d{3})+(\.\d+)?$`)
+
+func parseStats(weapon *types.Weapon) types.Stats {
+	stats := make(types.Stats)
+
+	for _, field := range weaponfields.Fields() {
+		if field.Text {
+			continue
+		}
+
+		if stat, ok := parseStat(field.Value(weapon), field.Unit); ok {
+			stats[field.Key] = stat
+		}
+	}
+
+	return stats
+}
+
+func parseStat(raw, unit string) (types.Stat, bool) {
+	raw = strings.TrimSpace(raw)
+
+	switch strings.ToLower(raw) {
+	case "", "-", "—", "n/a", "?":
+		return types.Stat{}, false
+	case "yes":
+		value := true
+		return types.Stat{Bool: &value}, true
+	case "no":
+		value := false
+		return types.Stat{Bool: &value}, true
+	}
+
+	if value, ok := parseNumber(raw); ok {
+		return types.Stat{Value: &value, Unit: unit}, true
+	}
+
+	parts := strings.Split(raw, "/")
+	if len(parts) < 2 {
+		return types.Stat{}, false
+	}
+
+	values := make([]float64, 0, len(parts))
+
+	for _, part := range parts {
+		value, ok := parseNumber(strings.TrimSpace(part))
+		if !ok {
+			return types.Stat{}, false
+		}
+		values = append(values, value)
+	}
+
+	return types.Stat{Range: values, Unit: unit}, true
+}
+
+func parseNumber(raw string) (float64, bool) {
+	if thousands.MatchString(raw) {
+		raw = strings.ReplaceAll(raw, ",", "")
+	} else if !strings.Contains(raw, ".") && strings.Count(raw, ",") == 1 {
+		raw = strings.Replace(raw, ",", ".", 1)
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,125 @@
+package weaponmapper
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStat(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	boolean := func(v bool) *bool { return &v }
+
+	tests := []struct {
+		name   string
+		raw    string
+		unit   string
+		want   types.Stat
+		wantOK bool
+	}{
+		{
+			name:   "number",
+			raw:    "85.5",
+			unit:   "kg",
+			want:   types.Stat{Value: float(85.5), Unit: "kg"},
+			wantOK: true,
+		},
+		{
+			name:   "number with comma",
+			raw:    " 0,3 ",
+			unit:   "s",
+			want:   types.Stat{Value: float(0.3), Unit: "s"},
+			wantOK: true,
+		},
+		{
+			name:   "thousands separator",
+			raw:    "1,000",
+			unit:   "m",
+			want:   types.Stat{Value: float(1000), Unit: "m"},
+			wantOK: true,
+		},
+		{
+			name:   "thousands separator with decimals",
+			raw:    "12,500.5",
+			unit:   "m",
+			want:   types.Stat{Value: float(12500.5), Unit: "m"},
+			wantOK: true,
+		},
+		{
+			name:   "decimal comma",
+			raw:    "1,25",
+			unit:   "s",
+			want:   types.Stat{Value: float(1.25), Unit: "s"},
+			wantOK: true,
+		},
+		{
+			name:   "yes",
+			raw:    "Yes",
+			want:   types.Stat{Bool: boolean(true)},
+			wantOK: true,
+		},
+		{
+			name:   "no",
+			raw:    "no",
+			want:   types.Stat{Bool: boolean(false)},
+			wantOK: true,
+		},
+		{
+			name:   "range",
+			raw:    "30 / 60",
+			unit:   "degrees",
+			want:   types.Stat{Range: []float64{30, 60}, Unit: "degrees"},
+			wantOK: true,
+		},
+		{
+			name:   "blank",
+			raw:    "  ",
+			wantOK: false,
+		},
+		{
+			name:   "dash",
+			raw:    "-",
+			wantOK: false,
+		},
+		{
+			name:   "text",
+			raw:    "HEAT",
+			wantOK: false,
+		},
+		{
+			name:   "text range",
+			raw:    "IR / TV",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, ok := parseStat(tt.raw, tt.unit)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestParseStats(t *testing.T) {
+	weapon := &types.Weapon{
+		Category:        "aam-ir-all-aspect",
+		Name:            "100",
+		Mass:            "85.5",
+		IRCCM:           "No",
+		Warhead:         "HE",
+		AdditionalNotes: "1",
+	}
+
+	stats := parseStats(weapon)
+
+	assert.Len(t, stats, 2)
+	assert.Equal(t, 85.5, *stats["mass"].Value)
+	assert.Equal(t, "kg", stats["mass"].Unit)
+	assert.False(t, *stats["irccm"].Bool)
+	assert.NotContains(t, stats, "name")
+	assert.NotContains(t, stats, "additional_notes")
+}
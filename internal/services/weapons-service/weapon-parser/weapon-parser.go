@@ -2,32 +2,55 @@ package weaponsparser
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	csvreader "github.com/erknas/wt-guided-weapons/internal/lib/csv-reader"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 )
 
+var ErrEmptyTable = errors.New("empty table")
+
 type Mapper interface {
 	Map(data [][]string, category string, weaponIdx int) (*types.Weapon, error)
 }
 
+type parsedTable struct {
+	hash    string
+	labels  []string
+	weapons []*types.Weapon
+}
+
 type CSVWeaponParser struct {
-	reader csvreader.Reader
-	mapper Mapper
+	reader  csvreader.Reader
+	mapper  Mapper
+	cache   map[string]parsedTable
+	pending map[string]parsedTable
+	mu      sync.RWMutex
 }
 
 func New(reader csvreader.Reader, mapper Mapper) *CSVWeaponParser {
 	return &CSVWeaponParser{
-		reader: reader,
-		mapper: mapper,
+		reader:  reader,
+		mapper:  mapper,
+		cache:   make(map[string]parsedTable),
+		pending: make(map[string]parsedTable),
 	}
 }
 
-func (p *CSVWeaponParser) Parse(ctx context.Context, category, url string) ([]*types.Weapon, error) {
-	data, err := p.reader.Read(ctx, url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV: %w", err)
+func (p *CSVWeaponParser) Parse(category, url string, data [][]string) ([]*types.Weapon, error) {
+	if len(data) == 0 || len(data[0]) < 2 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyTable, category)
+	}
+
+	hash := csvreader.Hash(data)
+
+	if cached, ok := p.parsed(url, hash); ok {
+		return cloneWeapons(cached.weapons), nil
 	}
 
 	var weapons []*types.Weapon
@@ -40,5 +63,132 @@ func (p *CSVWeaponParser) Parse(ctx context.Context, category, url string) ([]*t
 		weapons = append(weapons, weapon)
 	}
 
-	return weapons, nil
+	p.mu.Lock()
+	p.pending[url] = parsedTable{hash: hash, labels: sheetLabels(data), weapons: weapons}
+	p.mu.Unlock()
+
+	return cloneWeapons(weapons), nil
+}
+
+func (p *CSVWeaponParser) Changed(ctx context.Context, url string) (bool, [][]string, error) {
+	data, err := p.reader.Read(ctx, url)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	p.mu.RLock()
+	cached, ok := p.cache[url]
+	p.mu.RUnlock()
+
+	return !ok || cached.hash != csvreader.Hash(data), data, nil
+}
+
+func (p *CSVWeaponParser) Seed(url, hash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.cache[url]; !ok {
+		p.cache[url] = parsedTable{hash: hash}
+	}
+}
+
+func (p *CSVWeaponParser) Commit(url, hash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	table, ok := p.pending[url]
+	if !ok || table.hash != hash {
+		return
+	}
+
+	p.cache[url] = table
+	delete(p.pending, url)
+}
+
+func (p *CSVWeaponParser) Labels(url string) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if table, ok := p.pending[url]; ok {
+		return table.labels
+	}
+
+	return p.cache[url].labels
+}
+
+func (p *CSVWeaponParser) Hash(url string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if table, ok := p.pending[url]; ok {
+		return table.hash
+	}
+
+	return p.cache[url].hash
+}
+
+func (p *CSVWeaponParser) parsed(url, hash string) (parsedTable, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, tables := range []map[string]parsedTable{p.pending, p.cache} {
+		if table, ok := tables[url]; ok && table.hash == hash && table.weapons != nil {
+			return table, true
+		}
+	}
+
+	return parsedTable{}, false
+}
+
+func sheetLabels(data [][]string) []string {
+	labels := make([]string, 0, len(data))
+
+	for _, row := range data {
+		if len(row) == 0 {
+			continue
+		}
+		if label := strings.TrimSpace(row[0]); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels
+}
+
+func cloneWeapons(weapons []*types.Weapon) []*types.Weapon {
+	clones := make([]*types.Weapon, 0, len(weapons))
+
+	for _, weapon := range weapons {
+		clone := *weapon
+		clone.Aliases = slices.Clone(weapon.Aliases)
+		clone.Extra = maps.Clone(weapon.Extra)
+		clone.Stats = cloneStats(weapon.Stats)
+		clone.Quantities = cloneStats(weapon.Quantities)
+		clones = append(clones, &clone)
+	}
+
+	return clones
+}
+
+func cloneStats(stats types.Stats) types.Stats {
+	if stats == nil {
+		return nil
+	}
+
+	clones := make(types.Stats, len(stats))
+
+	for key, stat := range stats {
+		if stat.Value != nil {
+			value := *stat.Value
+			stat.Value = &value
+		}
+		if stat.Bool != nil {
+			value := *stat.Bool
+			stat.Bool = &value
+		}
+		stat.Range = slices.Clone(stat.Range)
+		clones[key] = stat
+	}
+
+	return clones
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	csvreader "github.com/erknas/wt-guided-weapons/internal/lib/csv-reader"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -98,6 +99,26 @@ func TestParse(t *testing.T) {
 				assert.ErrorIs(t, err, context.DeadlineExceeded)
 			},
 		},
+		{
+			name: "fail empty table",
+			mocks: func(mr *mockReader, mm *mockMapper) {
+				mr.On("Read", mock.Anything, "test-url").Return([][]string{}, nil)
+			},
+			wantErr: true,
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrEmptyTable)
+			},
+		},
+		{
+			name: "fail header only table",
+			mocks: func(mr *mockReader, mm *mockMapper) {
+				mr.On("Read", mock.Anything, "test-url").Return([][]string{{"Name:"}, {"Mass:"}}, nil)
+			},
+			wantErr: true,
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrEmptyTable)
+			},
+		},
 		{
 			name: "fail Map error",
 			mocks: func(mr *mockReader, mm *mockMapper) {
@@ -121,7 +142,12 @@ func TestParse(t *testing.T) {
 				ctx = tt.ctx()
 			}
 
-			res, err := parser.Parse(ctx, "category", "test-url")
+			var res []*types.Weapon
+
+			_, data, err := parser.Changed(ctx, "test-url")
+			if err == nil {
+				res, err = parser.Parse("category", "test-url", data)
+			}
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -139,3 +165,89 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_Cache(t *testing.T) {
+	testWeapon := &types.Weapon{Category: "category", Name: "QN502C"}
+	testData := [][]string{{"Name:", "QN502C"}}
+	editedData := [][]string{{"Name:", "QN502C"}, {"Mass:", "20"}}
+
+	mockReader := new(mockReader)
+	mockMapper := new(mockMapper)
+
+	mockReader.On("Read", mock.Anything, "test-url").Return(testData, nil).Once()
+	mockMapper.On("Map", testData, "category", 1).Return(testWeapon, nil).Once()
+
+	parser := New(mockReader, mockMapper)
+
+	changed, data, err := parser.Changed(context.Background(), "test-url")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, testData, data)
+
+	res, err := parser.Parse("category", "test-url", data)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Weapon{testWeapon}, res)
+	assert.Equal(t, []string{"Name:"}, parser.Labels("test-url"))
+	assert.Nil(t, parser.Labels("unknown-url"))
+	assert.Equal(t, csvreader.Hash(testData), parser.Hash("test-url"))
+	assert.Empty(t, parser.Hash("unknown-url"))
+
+	res[0].Name = "QN502C-mutated"
+
+	mockReader.On("Read", mock.Anything, "test-url").Return(testData, nil).Twice()
+
+	changed, _, err = parser.Changed(context.Background(), "test-url")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	parser.Commit("test-url", csvreader.Hash(editedData))
+	parser.Commit("test-url", csvreader.Hash(testData))
+
+	changed, data, err = parser.Changed(context.Background(), "test-url")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	res, err = parser.Parse("category", "test-url", data)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Weapon{testWeapon}, res)
+	assert.NotSame(t, testWeapon, res[0])
+
+	mockReader.On("Read", mock.Anything, "test-url").Return(editedData, nil).Once()
+
+	changed, _, err = parser.Changed(context.Background(), "test-url")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	mockReader.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestParse_Seed(t *testing.T) {
+	testWeapon := &types.Weapon{Category: "category", Name: "QN502C"}
+	testData := [][]string{{"Name:", "QN502C"}}
+	editedData := [][]string{{"Name:", "QN502C"}, {"Mass:", "20"}}
+
+	mockReader := new(mockReader)
+	mockMapper := new(mockMapper)
+
+	parser := New(mockReader, mockMapper)
+	parser.Seed("test-url", csvreader.Hash(testData))
+
+	mockReader.On("Read", mock.Anything, "test-url").Return(testData, nil).Once()
+	mockMapper.On("Map", testData, "category", 1).Return(testWeapon, nil).Once()
+
+	changed, data, err := parser.Changed(context.Background(), "test-url")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, csvreader.Hash(testData), parser.Hash("test-url"))
+
+	res, err := parser.Parse("category", "test-url", data)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Weapon{testWeapon}, res)
+
+	parser.Seed("test-url", csvreader.Hash(editedData))
+	assert.Equal(t, csvreader.Hash(testData), parser.Hash("test-url"))
+
+	mockReader.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
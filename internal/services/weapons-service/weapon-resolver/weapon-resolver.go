@@ -0,0 +1,192 @@
+package weaponresolver
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	weaponid "github.com/erknas/wt-guided-weapons/internal/lib/weapon-id"
+	weaponsearch "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-search"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+const (
+	nameWeight   = 0.5
+	statsWeight  = 0.3
+	columnWeight = 0.2
+	threshold    = 0.75
+	statsMatch   = 0.9
+)
+
+var identityFields = map[string]struct{}{
+	"name":             {},
+	"additional_notes": {},
+}
+
+type candidate struct {
+	incoming *types.Weapon
+	stored   *types.Weapon
+	score    float64
+	reasons  []string
+}
+
+func Resolve(incoming, stored []*types.Weapon, categories []string) []types.WeaponMerge {
+	known := make(map[string]*types.Weapon, len(stored))
+	for _, weapon := range stored {
+		known[weapon.ID] = weapon
+		for _, alias := range weapon.Aliases {
+			known[alias] = weapon
+		}
+	}
+
+	claimed := make(map[string]struct{}, len(stored))
+	unresolved := make([]*types.Weapon, 0)
+
+	for _, weapon := range incoming {
+		id := weaponid.Generate(weapon)
+
+		if existing, ok := known[id]; ok {
+			if _, taken := claimed[existing.ID]; !taken {
+				weapon.ID = existing.ID
+				weapon.Aliases = existing.Aliases
+				claimed[existing.ID] = struct{}{}
+				continue
+			}
+		}
+
+		weapon.ID = id
+		unresolved = append(unresolved, weapon)
+	}
+
+	orphans := make([]*types.Weapon, 0)
+	for _, weapon := range stored {
+		if _, ok := claimed[weapon.ID]; ok || weapon.Retired {
+			continue
+		}
+		if slices.Contains(categories, weapon.Category) {
+			orphans = append(orphans, weapon)
+		}
+	}
+
+	var candidates []candidate
+	for _, weapon := range unresolved {
+		for _, orphan := range orphans {
+			if c, ok := match(weapon, orphan); ok {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].incoming.ID != candidates[j].incoming.ID {
+			return candidates[i].incoming.ID < candidates[j].incoming.ID
+		}
+		return candidates[i].stored.ID < candidates[j].stored.ID
+	})
+
+	resolved := make(map[*types.Weapon]struct{}, len(unresolved))
+
+	var merges []types.WeaponMerge
+
+	for _, c := range candidates {
+		if _, ok := resolved[c.incoming]; ok {
+			continue
+		}
+		if _, ok := claimed[c.stored.ID]; ok {
+			continue
+		}
+
+		alias := c.incoming.ID
+
+		c.incoming.ID = c.stored.ID
+		c.incoming.Aliases = appendAlias(c.stored.Aliases, alias)
+
+		resolved[c.incoming] = struct{}{}
+		claimed[c.stored.ID] = struct{}{}
+
+		merges = append(merges, types.WeaponMerge{
+			WeaponID:     c.stored.ID,
+			Alias:        alias,
+			FromName:     c.stored.Name,
+			ToName:       c.incoming.Name,
+			FromCategory: c.stored.Category,
+			ToCategory:   c.incoming.Category,
+			Score:        math.Round(c.score*100) / 100,
+			Reasons:      c.reasons,
+		})
+	}
+
+	return merges
+}
+
+func match(incoming, stored *types.Weapon) (candidate, bool) {
+	sameCategory := incoming.Category == stored.Category
+
+	name := weaponsearch.Similarity(incoming.Name, stored.Name)
+	if !sameCategory && name < 1 {
+		return candidate{}, false
+	}
+
+	stats := statsSimilarity(incoming, stored)
+	score := nameWeight*name + statsWeight*stats
+
+	var reasons []string
+
+	if sameCategory && incoming.Column > 0 && incoming.Column == stored.Column {
+		score += columnWeight
+		reasons = append(reasons, types.MatchColumn)
+	}
+
+	if name == 1 {
+		reasons = append(reasons, types.MatchName)
+	} else if name > 0 {
+		reasons = append(reasons, types.MatchFuzzyName)
+	}
+
+	if stats >= statsMatch {
+		reasons = append(reasons, types.MatchStats)
+	}
+
+	if score < threshold {
+		return candidate{}, false
+	}
+
+	return candidate{incoming: incoming, stored: stored, score: score, reasons: reasons}, true
+}
+
+func statsSimilarity(a, b *types.Weapon) float64 {
+	total, equal := 0, 0
+
+	for _, field := range weaponfields.Fields() {
+		if _, ok := identityFields[field.Key]; ok {
+			continue
+		}
+
+		va, vb := field.Value(a), field.Value(b)
+		if va == "" && vb == "" {
+			continue
+		}
+
+		total++
+		if va == vb {
+			equal++
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(equal) / float64(total)
+}
+
+func appendAlias(aliases []string, alias string) []string {
+	if slices.Contains(aliases, alias) {
+		return aliases
+	}
+	return append(slices.Clone(aliases), alias)
+}
@@ -0,0 +1,130 @@
+package weaponresolver
+
+import (
+	"testing"
+
+	weaponid "github.com/erknas/wt-guided-weapons/internal/lib/weapon-id"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	aim9l := &types.Weapon{Category: "aam-ir-all-aspect", Name: "AIM-9L"}
+
+	tests := []struct {
+		name        string
+		incoming    *types.Weapon
+		stored      []*types.Weapon
+		categories  []string
+		wantID      string
+		wantAliases int
+		wantReasons []string
+	}{
+		{
+			name:       "exact",
+			incoming:   &types.Weapon{Category: "aam-ir-all-aspect", Name: "AIM-9L"},
+			stored:     []*types.Weapon{{ID: weaponid.Generate(aim9l), Category: "aam-ir-all-aspect", Name: "AIM-9L"}},
+			categories: []string{"aam-ir-all-aspect"},
+			wantID:     weaponid.Generate(aim9l),
+		},
+		{
+			name:        "exact alias",
+			incoming:    &types.Weapon{Category: "aam-ir-all-aspect", Name: "AIM-9L"},
+			stored:      []*types.Weapon{{ID: "1", Aliases: []string{weaponid.Generate(aim9l)}, Category: "aam-ir-all-aspect", Name: "AIM-9L"}},
+			categories:  []string{"aam-ir-all-aspect"},
+			wantID:      "1",
+			wantAliases: 1,
+		},
+		{
+			name:        "notes edited",
+			incoming:    &types.Weapon{Category: "agm-salh", Name: "Kh-29L", AdditionalNotes: "Su-17M2", Mass: "657", Column: 2},
+			stored:      []*types.Weapon{{ID: "1", Category: "agm-salh", Name: "Kh-29L", AdditionalNotes: "Su-17", Mass: "657", Column: 2}},
+			categories:  []string{"agm-salh"},
+			wantID:      "1",
+			wantAliases: 1,
+			wantReasons: []string{types.MatchColumn, types.MatchName, types.MatchStats},
+		},
+		{
+			name:        "renamed",
+			incoming:    &types.Weapon{Category: "aam-arh", Name: "AIM-120C-5", Mass: "157", Warhead: "HE", Column: 5},
+			stored:      []*types.Weapon{{ID: "1", Category: "aam-arh", Name: "AIN-120C-5", Mass: "157", Warhead: "HE", Column: 5}},
+			categories:  []string{"aam-arh"},
+			wantID:      "1",
+			wantAliases: 1,
+			wantReasons: []string{types.MatchColumn, types.MatchFuzzyName, types.MatchStats},
+		},
+		{
+			name:        "moved between tables",
+			incoming:    &types.Weapon{Category: "sam-ir-naval", Name: "Mistral", Mass: "18.7", Caliber: "90"},
+			stored:      []*types.Weapon{{ID: "1", Category: "sam-ir", Name: "Mistral", Mass: "18.7", Caliber: "90", Column: 3}},
+			categories:  []string{"sam-ir", "sam-ir-naval"},
+			wantID:      "1",
+			wantAliases: 1,
+			wantReasons: []string{types.MatchName, types.MatchStats},
+		},
+		{
+			name:       "different weapon in same column",
+			incoming:   &types.Weapon{Category: "aam-arh", Name: "R-77", Mass: "175", Column: 5},
+			stored:     []*types.Weapon{{ID: "1", Category: "aam-arh", Name: "AIM-120A", Mass: "157", Column: 5}},
+			categories: []string{"aam-arh"},
+		},
+		{
+			name:       "retired weapon",
+			incoming:   &types.Weapon{Category: "aam-arh", Name: "AIM-120A", Mass: "157", Column: 5},
+			stored:     []*types.Weapon{{ID: "1", Category: "aam-arh", Name: "AIM-120A", AdditionalNotes: "old", Mass: "157", Column: 5, Retired: true}},
+			categories: []string{"aam-arh"},
+		},
+		{
+			name:     "category not parsed",
+			incoming: &types.Weapon{Category: "aam-arh", Name: "AIM-120A", Mass: "157", Column: 5},
+			stored:   []*types.Weapon{{ID: "1", Category: "aam-arh", Name: "AIM-120A", AdditionalNotes: "old", Mass: "157", Column: 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fresh := weaponid.Generate(tt.incoming)
+
+			merges := Resolve([]*types.Weapon{tt.incoming}, tt.stored, tt.categories)
+
+			if tt.wantID == "" {
+				assert.Equal(t, fresh, tt.incoming.ID)
+				assert.Empty(t, merges)
+				return
+			}
+
+			assert.Equal(t, tt.wantID, tt.incoming.ID)
+			assert.Len(t, tt.incoming.Aliases, tt.wantAliases)
+
+			if tt.wantReasons == nil {
+				assert.Empty(t, merges)
+				return
+			}
+
+			require.Len(t, merges, 1)
+			assert.Equal(t, tt.wantID, merges[0].WeaponID)
+			assert.Equal(t, fresh, merges[0].Alias)
+			assert.Equal(t, tt.wantReasons, merges[0].Reasons)
+			assert.Equal(t, tt.stored[0].Category, merges[0].FromCategory)
+			assert.Equal(t, tt.incoming.Category, merges[0].ToCategory)
+		})
+	}
+}
+
+func TestResolve_BestMatch(t *testing.T) {
+	incoming := []*types.Weapon{
+		{Category: "aam-arh", Name: "AIM-54A-60", Mass: "443", Column: 1},
+		{Category: "aam-arh", Name: "AIM-54C-60", Mass: "463", Column: 2},
+	}
+	stored := []*types.Weapon{
+		{ID: "a", Category: "aam-arh", Name: "AIM-54A", Mass: "443", Column: 1},
+		{ID: "c", Category: "aam-arh", Name: "AIM-54C", Mass: "463", Column: 2},
+	}
+
+	merges := Resolve(incoming, stored, []string{"aam-arh"})
+
+	assert.Len(t, merges, 2)
+	assert.Equal(t, "a", incoming[0].ID)
+	assert.Equal(t, "c", incoming[1].ID)
+}
@@ -0,0 +1,45 @@
+package weaponsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "AIM-9M", want: "aim9m"},
+		{input: "aim 9m", want: "aim9m"},
+		{input: "R-27ЭР", want: "r27er"},
+		{input: "Х-29Л", want: "kh29l"},
+		{input: "9M39 Igla", want: "9m39igla"},
+		{input: " - ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.input))
+		})
+	}
+}
+
+func TestTokens(t *testing.T) {
+	assert.Equal(t, []string{"r", "27er", "semi", "active"}, Tokens("R-27ЭР (semi-active)"))
+	assert.Empty(t, Tokens(""))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("aim9m", "aim9m"))
+	assert.Equal(t, 1, distance("aim9m", "aim9l"))
+	assert.Equal(t, 1, distance("aim9", "aim9m"))
+	assert.Equal(t, 3, distance("kitten", "sitting"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("AIM-9M", "aim 9m"))
+	assert.Equal(t, 0.8, Similarity("AIM-9M", "AIM-9L"))
+	assert.Equal(t, 0.0, Similarity("", ""))
+}
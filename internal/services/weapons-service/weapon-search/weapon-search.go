@@ -0,0 +1,185 @@
+package weaponsearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+const (
+	exactScore     = 1.0
+	prefixScore    = 0.9
+	substringScore = 0.8
+	fuzzyScore     = 0.7
+	aliasWeight    = 0.95
+	guidanceWeight = 0.5
+	warheadWeight  = 0.5
+	notesWeight    = 0.4
+	minTokenLen    = 2
+)
+
+var aliases = map[string]string{
+	"sidewinder": "aim9",
+	"sparrow":    "aim7",
+	"amraam":     "aim120",
+	"phoenix":    "aim54",
+	"maverick":   "agm65",
+	"hellfire":   "agm114",
+	"stinger":    "fim92",
+	"aphid":      "r60",
+	"archer":     "r73",
+	"alamo":      "r27",
+	"adder":      "r77",
+	"kerry":      "kh25",
+	"karen":      "kh25",
+	"kedge":      "kh29",
+	"magic":      "r550",
+}
+
+var aliasNames = sortedAliases()
+
+func sortedAliases() []string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Search(query string, weapons []*types.Weapon) []types.SearchResult {
+	q := Normalize(query)
+	if q == "" {
+		return []types.SearchResult{}
+	}
+
+	tokens := Tokens(query)
+	results := make([]types.SearchResult, 0)
+
+	for _, weapon := range weapons {
+		score := score(q, tokens, weapon)
+		if score <= 0 {
+			continue
+		}
+
+		results = append(results, types.SearchResult{
+			ID:       weapon.ID,
+			Name:     weapon.Name,
+			Category: weapon.Category,
+			Score:    math.Round(score*100) / 100,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	return results
+}
+
+func score(q string, tokens []string, weapon *types.Weapon) float64 {
+	name := Normalize(weapon.Name)
+
+	score := matchScore(q, name)
+
+	if target, ok := alias(q); ok && strings.HasPrefix(name, target) {
+		score = max(score, aliasWeight*prefixScore)
+	}
+
+	score = max(score,
+		guidanceWeight*tokensScore(tokens, Tokens(weapon.GuidanceType)),
+		warheadWeight*tokensScore(tokens, Tokens(weapon.Warhead)),
+		notesWeight*tokensScore(tokens, Tokens(weapon.AdditionalNotes)),
+	)
+
+	return score
+}
+
+func matchScore(q, name string) float64 {
+	switch {
+	case name == "":
+		return 0
+	case q == name:
+		return exactScore
+	case strings.HasPrefix(name, q):
+		return prefixScore
+	case strings.Contains(name, q):
+		return substringScore
+	}
+
+	d := distance(q, name)
+	if runes, n := []rune(name), len([]rune(q)); len(runes) > n {
+		d = min(d, distance(q, string(runes[:n])))
+	}
+
+	if d > maxDistance(q) {
+		return 0
+	}
+
+	return fuzzyScore * (1 - float64(d)/float64(len([]rune(q))+1))
+}
+
+func tokensScore(query, field []string) float64 {
+	if len(field) == 0 {
+		return 0
+	}
+
+	matched, total := 0, 0
+
+	for _, q := range query {
+		if len(q) < minTokenLen {
+			continue
+		}
+		total++
+
+		for _, f := range field {
+			if f == q || (len(q) > 3 && strings.HasPrefix(f, q)) || distance(q, f) <= maxDistance(q) {
+				matched++
+				break
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(matched) / float64(total)
+}
+
+func alias(q string) (string, bool) {
+	if target, ok := aliases[q]; ok {
+		return target, true
+	}
+
+	if maxDistance(q) == 0 {
+		return "", false
+	}
+
+	for _, name := range aliasNames {
+		if distance(q, name) <= maxDistance(q) {
+			return aliases[name], true
+		}
+	}
+
+	return "", false
+}
+
+func maxDistance(q string) int {
+	switch n := len([]rune(q)); {
+	case n < 5:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,62 @@
+package weaponsearch
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var weapons = []*types.Weapon{
+	{ID: "1", Name: "AIM-9M", Category: "aam-ir-all-aspect", GuidanceType: "IR"},
+	{ID: "2", Name: "AIM-9L", Category: "aam-ir-all-aspect", GuidanceType: "IR"},
+	{ID: "3", Name: "R-27ER", Category: "aam-sarh", GuidanceType: "SARH", Warhead: "Expanding rod"},
+	{ID: "4", Name: "AIM-120A", Category: "aam-arh", GuidanceType: "ARH", AdditionalNotes: "Datalink, lofting"},
+	{ID: "5", Name: "9M39 Igla", Category: "sam-ir", GuidanceType: "IR"},
+}
+
+func names(results []types.SearchResult) []string {
+	out := make([]string, 0, len(results))
+	for _, result := range results {
+		out = append(out, result.Name)
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "compact name", query: "aim9m", want: []string{"AIM-9M", "AIM-9L"}},
+		{name: "spaced name", query: "AIM 9M", want: []string{"AIM-9M", "AIM-9L"}},
+		{name: "prefix ranks all", query: "aim", want: []string{"AIM-120A", "AIM-9L", "AIM-9M"}},
+		{name: "cyrillic transliteration", query: "R-27ЭР", want: []string{"R-27ER"}},
+		{name: "typo", query: "aim-9n", want: []string{"AIM-9L", "AIM-9M"}},
+		{name: "alias", query: "Sidewinder", want: []string{"AIM-9L", "AIM-9M"}},
+		{name: "alias typo", query: "sidewindr", want: []string{"AIM-9L", "AIM-9M"}},
+		{name: "notes", query: "lofting", want: []string{"AIM-120A"}},
+		{name: "guidance", query: "sarh", want: []string{"R-27ER"}},
+		{name: "warhead", query: "expanding rod", want: []string{"R-27ER"}},
+		{name: "no match", query: "zzzz", want: []string{}},
+		{name: "empty", query: "-", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(Search(tt.query, weapons)))
+		})
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	results := Search("aim-9m", weapons)
+
+	assert.Equal(t, "AIM-9M", results[0].Name)
+	assert.Equal(t, 1.0, results[0].Score)
+
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+}
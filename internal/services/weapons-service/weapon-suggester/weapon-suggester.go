@@ -0,0 +1,102 @@
+package weaponsuggester
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	weaponsearch "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-search"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+)
+
+type entry struct {
+	key  string
+	rank int
+	ref  types.WeaponRef
+}
+
+type Index struct {
+	entries []entry
+	mu      sync.RWMutex
+}
+
+func New() *Index {
+	return &Index{}
+}
+
+func (i *Index) Rebuild(weapons []*types.Weapon) {
+	entries := make([]entry, 0, len(weapons)*2)
+
+	for _, weapon := range weapons {
+		ref := types.WeaponRef{ID: weapon.ID, Name: weapon.Name, Category: weapon.Category}
+		tokens := weaponsearch.Tokens(weapon.Name)
+
+		for n := range tokens {
+			rank := 1
+			if n == 0 {
+				rank = 0
+			}
+			entries = append(entries, entry{
+				key:  strings.Join(tokens[n:], ""),
+				rank: rank,
+				ref:  ref,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].key < entries[b].key
+	})
+
+	i.mu.Lock()
+	i.entries = entries
+	i.mu.Unlock()
+}
+
+func (i *Index) Suggest(query string, limit int) []types.WeaponRef {
+	prefix := weaponsearch.Normalize(query)
+	if prefix == "" || limit <= 0 {
+		return []types.WeaponRef{}
+	}
+
+	i.mu.RLock()
+	start := sort.Search(len(i.entries), func(n int) bool {
+		return i.entries[n].key >= prefix
+	})
+
+	var matches []entry
+	for n := start; n < len(i.entries) && strings.HasPrefix(i.entries[n].key, prefix); n++ {
+		matches = append(matches, i.entries[n])
+	}
+	i.mu.RUnlock()
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		ma, mb := matches[a], matches[b]
+		if ma.rank != mb.rank {
+			return ma.rank < mb.rank
+		}
+		if len(ma.ref.Name) != len(mb.ref.Name) {
+			return len(ma.ref.Name) < len(mb.ref.Name)
+		}
+		if ma.ref.Name != mb.ref.Name {
+			return ma.ref.Name < mb.ref.Name
+		}
+		return ma.ref.ID < mb.ref.ID
+	})
+
+	suggestions := make([]types.WeaponRef, 0, min(limit, len(matches)))
+	seen := make(map[string]struct{}, len(matches))
+
+	for _, match := range matches {
+		if len(suggestions) == limit {
+			break
+		}
+		if _, ok := seen[match.ref.ID]; ok {
+			continue
+		}
+		seen[match.ref.ID] = struct{}{}
+		suggestions = append(suggestions, match.ref)
+	}
+
+	return suggestions
+}
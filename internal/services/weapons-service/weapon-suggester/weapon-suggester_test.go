@@ -0,0 +1,59 @@
+package weaponsuggester
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func names(refs []types.WeaponRef) []string {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, ref.Name)
+	}
+	return out
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	index := New()
+	index.Rebuild([]*types.Weapon{
+		{ID: "1", Name: "AIM-9M", Category: "aam-ir-all-aspect"},
+		{ID: "2", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+		{ID: "3", Name: "AIM-120A", Category: "aam-arh"},
+		{ID: "4", Name: "9M39 Igla", Category: "sam-ir"},
+		{ID: "5", Name: "Igla-S", Category: "sam-ir"},
+		{ID: "6", Name: "R-27ER", Category: "aam-sarh"},
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "name prefix", query: "aim-9", limit: 10, want: []string{"AIM-9L", "AIM-9M"}},
+		{name: "shorter names first", query: "aim", limit: 10, want: []string{"AIM-9L", "AIM-9M", "AIM-120A"}},
+		{name: "bounded", query: "aim", limit: 1, want: []string{"AIM-9L"}},
+		{name: "token prefix ranks after name prefix", query: "igla", limit: 10, want: []string{"Igla-S", "9M39 Igla"}},
+		{name: "cyrillic", query: "Р-27", limit: 10, want: []string{"R-27ER"}},
+		{name: "no match", query: "zz", limit: 10, want: []string{}},
+		{name: "empty query", query: "", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(index.Suggest(tt.query, tt.limit)))
+		})
+	}
+}
+
+func TestIndex_Rebuild(t *testing.T) {
+	index := New()
+	index.Rebuild([]*types.Weapon{{ID: "1", Name: "AIM-9M"}})
+	assert.Len(t, index.Suggest("aim", 10), 1)
+
+	index.Rebuild([]*types.Weapon{{ID: "2", Name: "R-73"}})
+	assert.Empty(t, index.Suggest("aim", 10))
+	assert.Len(t, index.Suggest("r73", 10), 1)
+}
@@ -2,7 +2,9 @@ package weaponsaggregator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,20 +13,45 @@ import (
 )
 
 type WeaponParser interface {
-	Parse(ctx context.Context, category string, url string) ([]*types.Weapon, error)
+	Parse(category string, url string, data [][]string) ([]*types.Weapon, error)
+	Changed(ctx context.Context, url string) (bool, [][]string, error)
+	Labels(url string) []string
+	Hash(url string) string
+	Seed(url, hash string)
+	Commit(url, hash string)
 }
 
 type Weapons struct {
-	urls   map[string]string
-	parser WeaponParser
-	log    *zap.Logger
+	tables     types.Tables
+	parser     WeaponParser
+	log        *zap.Logger
+	workers    int
+	prefetched map[string]prefetchedTable
+	mu         sync.Mutex
 }
 
-func New(urls map[string]string, parser WeaponParser, log *zap.Logger) *Weapons {
+type prefetchedTable struct {
+	data      [][]string
+	fetchedAt time.Time
+}
+
+const (
+	versionKey     = "version"
+	defaultWorkers = 4
+	prefetchTTL    = time.Minute
+)
+
+func New(tables types.Tables, parser WeaponParser, log *zap.Logger, workers int) *Weapons {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
 	return &Weapons{
-		urls:   urls,
-		parser: parser,
-		log:    log,
+		tables:     tables,
+		parser:     parser,
+		log:        log,
+		workers:    workers,
+		prefetched: make(map[string]prefetchedTable),
 	}
 }
 
@@ -34,24 +61,32 @@ type parseJob struct {
 }
 
 type parseResult struct {
-	weapons  []*types.Weapon
-	category string
-	err      error
+	weapons   []*types.Weapon
+	labels    []string
+	hash      string
+	category  string
+	unchanged bool
+	err       error
 }
 
-const numWorkers = 4
+var errNoTables = errors.New("no tables parsed")
 
-func (w *Weapons) AggregateWeapons(ctx context.Context) ([]*types.Weapon, error) {
+func (w *Weapons) AggregateWeapons(ctx context.Context, req types.UpdateRequest) ([]*types.Weapon, []types.CategoryReport, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	jobsCh := make(chan parseJob, len(w.urls))
-	resultsCh := make(chan parseResult, len(w.urls))
+	jobs, err := w.jobs(req.Categories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobsCh := make(chan parseJob, len(jobs))
+	resultsCh := make(chan parseResult, len(jobs))
 
 	wg := &sync.WaitGroup{}
 
 	start := time.Now()
-	for i := range numWorkers {
+	for i := range min(w.workers, len(jobs)) {
 		wg.Add(1)
 		w.log.Debug("starting", zap.Int("worker №", i+1))
 		go w.worker(ctx, jobsCh, resultsCh, wg)
@@ -60,14 +95,11 @@ func (w *Weapons) AggregateWeapons(ctx context.Context) ([]*types.Weapon, error)
 	go func() {
 		defer close(jobsCh)
 
-		for category, url := range w.urls {
-			if category == "version" {
-				continue
-			}
+		for _, job := range jobs {
 			select {
-			case jobsCh <- parseJob{category: category, url: url}:
+			case jobsCh <- job:
 				w.log.Debug("sending job",
-					zap.String("table", fmt.Sprintf("%s | %s", category, url)),
+					zap.String("table", fmt.Sprintf("%s | %s", job.category, job.url)),
 				)
 			case <-ctx.Done():
 				w.log.Error("Context cancelled on sending job")
@@ -81,8 +113,12 @@ func (w *Weapons) AggregateWeapons(ctx context.Context) ([]*types.Weapon, error)
 		close(resultsCh)
 	}()
 
-	var weapons []*types.Weapon
-	tables := 0
+	var (
+		weapons []*types.Weapon
+		reports []types.CategoryReport
+		lastErr error
+	)
+	tables, skipped := 0, 0
 
 	for result := range resultsCh {
 		if result.err != nil {
@@ -91,41 +127,250 @@ func (w *Weapons) AggregateWeapons(ctx context.Context) ([]*types.Weapon, error)
 				zap.String("category", result.category),
 				zap.Int("total tables", tables),
 			)
-			cancel()
-			return nil, fmt.Errorf("failed to parse table: %w", result.err)
+
+			if !req.Partial {
+				cancel()
+				return nil, nil, fmt.Errorf("failed to parse table: %w", result.err)
+			}
+
+			reports = append(reports, types.CategoryReport{
+				Category: result.category,
+				Status:   types.CategoryStatusFailed,
+				Error:    result.err.Error(),
+			})
+			lastErr = result.err
+			continue
+		}
+
+		if result.unchanged {
+			w.log.Info("Table unchanged, skipped",
+				zap.String("category", result.category),
+			)
+
+			reports = append(reports, types.CategoryReport{
+				Category: result.category,
+				Status:   types.CategoryStatusUnchanged,
+				Hash:     result.hash,
+			})
+			skipped++
+			continue
 		}
 
 		w.log.Info("Table successfully parsed",
 			zap.String("category", result.category),
 		)
 
+		reports = append(reports, types.CategoryReport{
+			Category: result.category,
+			Status:   types.CategoryStatusOK,
+			Weapons:  len(result.weapons),
+			Hash:     result.hash,
+			Labels:   result.labels,
+		})
 		weapons = append(weapons, result.weapons...)
 		tables++
 	}
 
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Category < reports[j].Category
+	})
+
+	if tables == 0 && lastErr != nil {
+		return nil, reports, fmt.Errorf("failed to parse table: %w: %w", errNoTables, lastErr)
+	}
+
 	w.log.Info("Tables parsing complited",
 		zap.Int("total tables", tables),
+		zap.Int("skipped tables", skipped),
+		zap.Int("failed tables", len(reports)-tables-skipped),
 		zap.Int("total weapons", len(weapons)),
 		zap.Duration("took time", time.Since(start)),
 	)
 
-	return weapons, nil
+	return weapons, reports, nil
+}
+
+func (w *Weapons) Changed(ctx context.Context, categories []string) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type checkResult struct {
+		category string
+		changed  bool
+		err      error
+	}
+
+	jobs, err := w.jobs(categories)
+	if err != nil {
+		return false, err
+	}
+
+	resultsCh := make(chan checkResult, len(jobs))
+	sem := make(chan struct{}, w.workers)
+
+	wg := &sync.WaitGroup{}
+
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			changed, data, err := w.parser.Changed(ctx, job.url)
+			if changed {
+				w.prefetch(job.url, data)
+			}
+			resultsCh <- checkResult{category: job.category, changed: changed, err: err}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	for result := range resultsCh {
+		if result.err != nil {
+			w.log.Error("Changed error",
+				zap.Error(result.err),
+				zap.String("category", result.category),
+			)
+			return false, fmt.Errorf("failed to check table: %w", result.err)
+		}
+
+		if result.changed {
+			w.log.Info("Table content changed",
+				zap.String("category", result.category),
+			)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (w *Weapons) Seed(hashes map[string]string) {
+	for category, hash := range hashes {
+		table, ok := w.tables[category]
+		if !ok || category == versionKey || hash == "" {
+			continue
+		}
+		w.parser.Seed(table.URL, hash)
+	}
+
+	w.log.Debug("Seed complited",
+		zap.Int("total tables", len(hashes)),
+	)
+}
+
+func (w *Weapons) Commit(hashes map[string]string) {
+	for category, hash := range hashes {
+		table, ok := w.tables[category]
+		if !ok || category == versionKey || hash == "" {
+			continue
+		}
+		w.parser.Commit(table.URL, hash)
+	}
+
+	w.log.Debug("Commit complited",
+		zap.Int("total tables", len(hashes)),
+	)
+}
+
+func (w *Weapons) jobs(categories []string) ([]parseJob, error) {
+	var jobs []parseJob
+
+	if len(categories) == 0 {
+		for category, table := range w.tables {
+			if category == versionKey {
+				continue
+			}
+			jobs = append(jobs, parseJob{category: category, url: table.URL})
+		}
+	} else {
+		for _, category := range categories {
+			table, ok := w.tables[category]
+			if !ok || category == versionKey {
+				return nil, fmt.Errorf("unknown category %s", category)
+			}
+			jobs = append(jobs, parseJob{category: category, url: table.URL})
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		pi, pj := w.tables[jobs[i].category].Priority, w.tables[jobs[j].category].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return jobs[i].category < jobs[j].category
+	})
+
+	return jobs, nil
 }
 
 func (w *Weapons) worker(ctx context.Context, jobsCh <-chan parseJob, resultsCh chan<- parseResult, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for job := range jobsCh {
-		weapons, err := w.parser.Parse(ctx, job.category, job.url)
 		select {
-		case resultsCh <- parseResult{
-			weapons:  weapons,
-			category: job.category,
-			err:      err,
-		}:
+		case resultsCh <- w.parse(ctx, job):
 		case <-ctx.Done():
 			w.log.Error("Context cancelled on sending results")
 			return
 		}
 	}
 }
+
+func (w *Weapons) parse(ctx context.Context, job parseJob) parseResult {
+	data, ok := w.takePrefetched(job.url)
+	if !ok {
+		changed, fetched, err := w.parser.Changed(ctx, job.url)
+		if err != nil {
+			return parseResult{category: job.category, err: err}
+		}
+
+		if !changed {
+			return parseResult{
+				hash:      w.parser.Hash(job.url),
+				category:  job.category,
+				unchanged: true,
+			}
+		}
+
+		data = fetched
+	}
+
+	weapons, err := w.parser.Parse(job.category, job.url, data)
+	if err != nil {
+		return parseResult{category: job.category, err: err}
+	}
+
+	return parseResult{
+		weapons:  weapons,
+		labels:   w.parser.Labels(job.url),
+		hash:     w.parser.Hash(job.url),
+		category: job.category,
+	}
+}
+
+func (w *Weapons) prefetch(url string, data [][]string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.prefetched[url] = prefetchedTable{data: data, fetchedAt: time.Now()}
+}
+
+func (w *Weapons) takePrefetched(url string) ([][]string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	table, ok := w.prefetched[url]
+	if !ok {
+		return nil, false
+	}
+	delete(w.prefetched, url)
+
+	return table.data, time.Since(table.fetchedAt) < prefetchTTL
+}
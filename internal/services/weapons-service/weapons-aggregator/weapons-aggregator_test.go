@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
@@ -16,15 +17,40 @@ type mockTableParser struct {
 	mock.Mock
 }
 
-func (m *mockTableParser) Parse(ctx context.Context, category, url string) ([]*types.Weapon, error) {
-	args := m.Called(ctx, category, url)
+func (m *mockTableParser) Parse(category, url string, data [][]string) ([]*types.Weapon, error) {
+	args := m.Called(category, url, data)
 	return args.Get(0).([]*types.Weapon), args.Error(1)
 }
 
+func (m *mockTableParser) Labels(url string) []string {
+	args := m.Called(url)
+	labels, _ := args.Get(0).([]string)
+	return labels
+}
+
+func (m *mockTableParser) Hash(url string) string {
+	args := m.Called(url)
+	return args.String(0)
+}
+
+func (m *mockTableParser) Changed(ctx context.Context, url string) (bool, [][]string, error) {
+	args := m.Called(ctx, url)
+	data, _ := args.Get(1).([][]string)
+	return args.Bool(0), data, args.Error(2)
+}
+
+func (m *mockTableParser) Commit(url, hash string) {
+	m.Called(url, hash)
+}
+
+func (m *mockTableParser) Seed(url, hash string) {
+	m.Called(url, hash)
+}
+
 func TestAggregate(t *testing.T) {
-	urls := map[string]string{
-		"aam-sarh": "https://docs.google.com/spreadsheets/d/1SsOpw9LAKOs0V5FBnv1VqAlu3OssmX7DJaaVAUREw78/export?format=csv&gid=128448244",
-		"aam-arh":  "https://docs.google.com/spreadsheets/d/1SsOpw9LAKOs0V5FBnv1VqAlu3OssmX7DJaaVAUREw78/export?format=csv&gid=650249168",
+	tables := types.Tables{
+		"aam-sarh": {URL: "https://docs.google.com/spreadsheets/d/1SsOpw9LAKOs0V5FBnv1VqAlu3OssmX7DJaaVAUREw78/export?format=csv&gid=128448244"},
+		"aam-arh":  {URL: "https://docs.google.com/spreadsheets/d/1SsOpw9LAKOs0V5FBnv1VqAlu3OssmX7DJaaVAUREw78/export?format=csv&gid=650249168"},
 	}
 
 	aamSarh := []*types.Weapon{
@@ -47,16 +73,16 @@ func TestAggregate(t *testing.T) {
 		{
 			name: "success",
 			mocks: func(mtp *mockTableParser) {
-				mtp.On("Parse", mock.Anything, "aam-sarh", urls["aam-sarh"]).Return(aamSarh, nil)
-				mtp.On("Parse", mock.Anything, "aam-arh", urls["aam-arh"]).Return(aamArh, nil)
+				mtp.On("Parse", "aam-sarh", tables["aam-sarh"].URL, mock.Anything).Return(aamSarh, nil)
+				mtp.On("Parse", "aam-arh", tables["aam-arh"].URL, mock.Anything).Return(aamArh, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "fail Parse error",
 			mocks: func(mtp *mockTableParser) {
-				mtp.On("Parse", mock.Anything, "aam-sarh", urls["aam-sarh"]).Return([]*types.Weapon{}, errors.New("failed to read CSV"))
-				mtp.On("Parse", mock.Anything, "aam-arh", urls["aam-arh"]).Return(aamArh, nil)
+				mtp.On("Parse", "aam-sarh", tables["aam-sarh"].URL, mock.Anything).Return([]*types.Weapon{}, errors.New("failed to read CSV"))
+				mtp.On("Parse", "aam-arh", tables["aam-arh"].URL, mock.Anything).Return(aamArh, nil)
 			},
 			wantErr: true,
 			checkErr: func(t *testing.T, err error) {
@@ -66,8 +92,8 @@ func TestAggregate(t *testing.T) {
 		{
 			name: "fail Map error",
 			mocks: func(mtp *mockTableParser) {
-				mtp.On("Parse", mock.Anything, "aam-sarh", urls["aam-sarh"]).Return([]*types.Weapon{}, errors.New("invalid data"))
-				mtp.On("Parse", mock.Anything, "aam-arh", urls["aam-arh"]).Return(aamArh, nil)
+				mtp.On("Parse", "aam-sarh", tables["aam-sarh"].URL, mock.Anything).Return([]*types.Weapon{}, errors.New("invalid data"))
+				mtp.On("Parse", "aam-arh", tables["aam-arh"].URL, mock.Anything).Return(aamArh, nil)
 			},
 			wantErr: true,
 			checkErr: func(t *testing.T, err error) {
@@ -79,16 +105,19 @@ func TestAggregate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockParser := new(mockTableParser)
+			mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+			mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+			mockParser.On("Changed", mock.Anything, mock.Anything).Return(true, nil, nil).Maybe()
 			tt.mocks(mockParser)
 
-			aggregator := New(urls, mockParser, zap.NewNop())
+			aggregator := New(tables, mockParser, zap.NewNop(), 2)
 
 			ctx := context.Background()
 			if tt.ctx != nil {
 				ctx = tt.ctx()
 			}
 
-			res, err := aggregator.AggregateWeapons(ctx)
+			res, reports, err := aggregator.AggregateWeapons(ctx, types.UpdateRequest{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -98,9 +127,331 @@ func TestAggregate(t *testing.T) {
 				assert.NotEmpty(t, res)
 				assert.ElementsMatch(t, append(aamSarh, aamArh...), res)
 				assert.Len(t, res, 4)
+				assert.Equal(t, []types.CategoryReport{
+					{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "hash", Labels: []string{"Name:"}},
+					{Category: "aam-sarh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "hash", Labels: []string{"Name:"}},
+				}, reports)
 			}
 
 			mock.AssertExpectationsForObjects(t)
 		})
 	}
 }
+
+func TestAggregate_Partial(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-sarh": {URL: "aam-sarh-url"},
+		"aam-arh":  {URL: "aam-arh-url"},
+	}
+
+	aamArh := []*types.Weapon{
+		{Name: "AAM-4", Category: "aam-arh"},
+		{Name: "AIM-54A Phoenix", Category: "aam-arh"},
+	}
+
+	t.Run("failed table skipped", func(t *testing.T) {
+		mockParser := new(mockTableParser)
+		mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+		mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+		mockParser.On("Changed", mock.Anything, mock.Anything).Return(true, nil, nil).Maybe()
+		mockParser.On("Parse", "aam-sarh", "aam-sarh-url", mock.Anything).Return([]*types.Weapon{}, errors.New("failed to read CSV"))
+		mockParser.On("Parse", "aam-arh", "aam-arh-url", mock.Anything).Return(aamArh, nil)
+
+		aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+		res, reports, err := aggregator.AggregateWeapons(context.Background(), types.UpdateRequest{Partial: true})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, aamArh, res)
+		assert.Equal(t, []types.CategoryReport{
+			{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "hash", Labels: []string{"Name:"}},
+			{Category: "aam-sarh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+		}, reports)
+	})
+
+	t.Run("all tables failed", func(t *testing.T) {
+		mockParser := new(mockTableParser)
+		mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+		mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+		mockParser.On("Changed", mock.Anything, mock.Anything).Return(true, nil, nil).Maybe()
+		mockParser.On("Parse", "aam-sarh", "aam-sarh-url", mock.Anything).Return([]*types.Weapon{}, errors.New("failed to read CSV"))
+		mockParser.On("Parse", "aam-arh", "aam-arh-url", mock.Anything).Return([]*types.Weapon{}, errors.New("failed to read CSV"))
+
+		aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+		res, reports, err := aggregator.AggregateWeapons(context.Background(), types.UpdateRequest{Partial: true})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errNoTables)
+		assert.Empty(t, res)
+		assert.Len(t, reports, 2)
+	})
+}
+
+func TestAggregate_Unchanged(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-sarh": {URL: "aam-sarh-url"},
+		"aam-arh":  {URL: "aam-arh-url"},
+	}
+
+	aamArh := []*types.Weapon{
+		{Name: "AAM-4", Category: "aam-arh"},
+		{Name: "AIM-54A Phoenix", Category: "aam-arh"},
+	}
+
+	tests := []struct {
+		name        string
+		mocks       func(*mockTableParser)
+		wantWeapons []*types.Weapon
+		wantReports []types.CategoryReport
+		wantErr     bool
+	}{
+		{
+			name: "unchanged table skipped",
+			mocks: func(mtp *mockTableParser) {
+				mtp.On("Changed", mock.Anything, "aam-sarh-url").Return(false, nil, nil)
+				mtp.On("Changed", mock.Anything, "aam-arh-url").Return(true, nil, nil)
+				mtp.On("Parse", "aam-arh", "aam-arh-url", mock.Anything).Return(aamArh, nil)
+			},
+			wantWeapons: aamArh,
+			wantReports: []types.CategoryReport{
+				{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "hash", Labels: []string{"Name:"}},
+				{Category: "aam-sarh", Status: types.CategoryStatusUnchanged, Hash: "hash"},
+			},
+		},
+		{
+			name: "fail Changed error",
+			mocks: func(mtp *mockTableParser) {
+				mtp.On("Changed", mock.Anything, "aam-sarh-url").Return(false, nil, errors.New("failed to read CSV"))
+				mtp.On("Changed", mock.Anything, "aam-arh-url").Return(false, nil, nil).Maybe()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := new(mockTableParser)
+			mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+			mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+			tt.mocks(mockParser)
+
+			aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+			res, reports, err := aggregator.AggregateWeapons(context.Background(), types.UpdateRequest{})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to parse table")
+			} else {
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tt.wantWeapons, res)
+				assert.Equal(t, tt.wantReports, reports)
+			}
+
+			mockParser.AssertNotCalled(t, "Parse", "aam-sarh", "aam-sarh-url", mock.Anything)
+		})
+	}
+}
+
+func TestChanged(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-sarh": {URL: "aam-sarh-url"},
+		"aam-arh":  {URL: "aam-arh-url"},
+	}
+
+	tests := []struct {
+		name    string
+		mocks   func(*mockTableParser)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "unchanged",
+			mocks: func(mtp *mockTableParser) {
+				mtp.On("Changed", mock.Anything, "aam-sarh-url").Return(false, nil, nil)
+				mtp.On("Changed", mock.Anything, "aam-arh-url").Return(false, nil, nil)
+			},
+			want: false,
+		},
+		{
+			name: "changed",
+			mocks: func(mtp *mockTableParser) {
+				mtp.On("Changed", mock.Anything, "aam-sarh-url").Return(false, nil, nil).Maybe()
+				mtp.On("Changed", mock.Anything, "aam-arh-url").Return(true, nil, nil)
+			},
+			want: true,
+		},
+		{
+			name: "fail Changed error",
+			mocks: func(mtp *mockTableParser) {
+				mtp.On("Changed", mock.Anything, "aam-sarh-url").Return(false, nil, errors.New("failed to read CSV"))
+				mtp.On("Changed", mock.Anything, "aam-arh-url").Return(false, nil, nil).Maybe()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := new(mockTableParser)
+			mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+			mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+			tt.mocks(mockParser)
+
+			aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+			changed, err := aggregator.Changed(context.Background(), nil)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to check table")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, changed)
+			}
+
+			mockParser.AssertNotCalled(t, "Changed", mock.Anything, "version-url")
+		})
+	}
+}
+
+func TestAggregate_Prefetched(t *testing.T) {
+	tables := types.Tables{
+		"version": {URL: "version-url"},
+		"aam-arh": {URL: "aam-arh-url"},
+	}
+
+	aamArh := []*types.Weapon{
+		{Name: "AAM-4", Category: "aam-arh"},
+	}
+	data := [][]string{{"Name:", "AAM-4"}}
+	req := types.UpdateRequest{Categories: []string{"aam-arh"}}
+
+	t.Run("reuses checked table", func(t *testing.T) {
+		mockParser := new(mockTableParser)
+		mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+		mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+		mockParser.On("Changed", mock.Anything, "aam-arh-url").Return(true, data, nil).Once()
+		mockParser.On("Parse", "aam-arh", "aam-arh-url", data).Return(aamArh, nil).Once()
+
+		aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+		changed, err := aggregator.Changed(context.Background(), req.Categories)
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		res, _, err := aggregator.AggregateWeapons(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, aamArh, res)
+
+		mockParser.AssertExpectations(t)
+		assert.Empty(t, aggregator.prefetched)
+	})
+
+	t.Run("rereads stale table", func(t *testing.T) {
+		fresh := [][]string{{"Name:", "AAM-4", "AIM-54A"}}
+
+		mockParser := new(mockTableParser)
+		mockParser.On("Labels", mock.Anything).Return([]string{"Name:"}).Maybe()
+		mockParser.On("Hash", mock.Anything).Return("hash").Maybe()
+		mockParser.On("Changed", mock.Anything, "aam-arh-url").Return(true, fresh, nil).Once()
+		mockParser.On("Parse", "aam-arh", "aam-arh-url", fresh).Return(aamArh, nil).Once()
+
+		aggregator := New(tables, mockParser, zap.NewNop(), 2)
+		aggregator.prefetched["aam-arh-url"] = prefetchedTable{data: data, fetchedAt: time.Now().Add(-2 * prefetchTTL)}
+
+		_, _, err := aggregator.AggregateWeapons(context.Background(), req)
+		require.NoError(t, err)
+
+		mockParser.AssertExpectations(t)
+	})
+}
+
+func TestSeed(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-sarh": {URL: "aam-sarh-url"},
+		"aam-arh":  {URL: "aam-arh-url"},
+	}
+
+	mockParser := new(mockTableParser)
+	mockParser.On("Seed", "aam-arh-url", "abc").Once()
+
+	aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+	aggregator.Seed(map[string]string{
+		"aam-arh":  "abc",
+		"aam-sarh": "",
+		"version":  "def",
+		"unknown":  "ghi",
+	})
+
+	mockParser.AssertExpectations(t)
+	mockParser.AssertNumberOfCalls(t, "Seed", 1)
+}
+
+func TestCommit(t *testing.T) {
+	tables := types.Tables{
+		"version":  {URL: "version-url"},
+		"aam-sarh": {URL: "aam-sarh-url"},
+		"aam-arh":  {URL: "aam-arh-url"},
+	}
+
+	mockParser := new(mockTableParser)
+	mockParser.On("Commit", "aam-arh-url", "abc").Once()
+
+	aggregator := New(tables, mockParser, zap.NewNop(), 2)
+
+	aggregator.Commit(map[string]string{
+		"aam-arh":  "abc",
+		"aam-sarh": "",
+		"version":  "def",
+		"unknown":  "ghi",
+	})
+
+	mockParser.AssertExpectations(t)
+	mockParser.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestJobs(t *testing.T) {
+	tables := types.Tables{
+		"version":      {URL: "version-url"},
+		"aam-sarh":     {URL: "aam-sarh-url", Priority: 10},
+		"aam-arh":      {URL: "aam-arh-url", Priority: 10},
+		"sam-ir-naval": {URL: "sam-ir-naval-url"},
+		"agm-tv":       {URL: "agm-tv-url", Priority: 5},
+	}
+
+	aggregator := New(tables, new(mockTableParser), zap.NewNop(), 0)
+
+	t.Run("all by priority", func(t *testing.T) {
+		jobs, err := aggregator.jobs(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []parseJob{
+			{category: "aam-arh", url: "aam-arh-url"},
+			{category: "aam-sarh", url: "aam-sarh-url"},
+			{category: "agm-tv", url: "agm-tv-url"},
+			{category: "sam-ir-naval", url: "sam-ir-naval-url"},
+		}, jobs)
+	})
+
+	t.Run("selected categories", func(t *testing.T) {
+		jobs, err := aggregator.jobs([]string{"sam-ir-naval", "aam-arh"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []parseJob{
+			{category: "aam-arh", url: "aam-arh-url"},
+			{category: "sam-ir-naval", url: "sam-ir-naval-url"},
+		}, jobs)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		_, err := aggregator.jobs([]string{"version"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown category version")
+	})
+}
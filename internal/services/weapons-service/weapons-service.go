@@ -2,116 +2,863 @@ package weaponsservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	csvreader "github.com/erknas/wt-guided-weapons/internal/lib/csv-reader"
+	"github.com/erknas/wt-guided-weapons/internal/lib/cursor"
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
 	"github.com/erknas/wt-guided-weapons/internal/logger"
+	weaponcomparator "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-comparator"
+	weaponmapper "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-mapper"
+	weaponresolver "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-resolver"
+	weaponsearch "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-search"
+	"github.com/erknas/wt-guided-weapons/internal/storage/mongodb"
 	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-type WeaponsUpserter interface {
-	UpsertWeapons(ctx context.Context, weapons []*types.Weapon) error
+type WeaponsStager interface {
+	Stage(ctx context.Context) (mongodb.Dataset, error)
+	Promote(ctx context.Context, dataset mongodb.Dataset) error
+	Discard(ctx context.Context, dataset mongodb.Dataset) error
 }
 
 type WeaponsProvider interface {
-	WeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error)
-	WeaponsByName(ctx context.Context, query string) ([]types.SearchResult, error)
+	Weapons(ctx context.Context, query types.WeaponsQuery) ([]*types.Weapon, error)
+	SearchableWeapons(ctx context.Context) ([]*types.Weapon, error)
+	WeaponByID(ctx context.Context, id string) (*types.Weapon, error)
+	WeaponsByIDs(ctx context.Context, ids []string) ([]*types.Weapon, error)
 }
 
 type WeaponsAggregator interface {
-	AggregateWeapons(ctx context.Context) ([]*types.Weapon, error)
+	AggregateWeapons(ctx context.Context, req types.UpdateRequest) ([]*types.Weapon, []types.CategoryReport, error)
+	Commit(hashes map[string]string)
 }
 
 type VersionUpdater interface {
-	UpdateVersion(ctx context.Context) error
+	LatestVersion(ctx context.Context) (types.VersionInfo, error)
 }
 
+type HistoryRecorder interface {
+	RecordSnapshots(ctx context.Context, version string, weapons []*types.Weapon) error
+	RecordMerges(ctx context.Context, merges []types.WeaponMerge) error
+	RecordIngest(ctx context.Context, ingest types.LastChange) error
+	CompleteIngest(ctx context.Context, ingest types.LastChange) error
+	Version(ctx context.Context) (types.LastChange, error)
+}
+
+type SchemaRecorder interface {
+	RecordSchemaReport(ctx context.Context, report types.SchemaReport) error
+	SchemaReport(ctx context.Context, version string) (types.SchemaReport, error)
+}
+
+type HistoryProvider interface {
+	WeaponHistory(ctx context.Context, id string) ([]types.WeaponSnapshot, error)
+	SnapshotsByVersion(ctx context.Context, version, category string) ([]types.WeaponSnapshot, error)
+}
+
+type Suggester interface {
+	Rebuild(weapons []*types.Weapon)
+	Suggest(query string, limit int) []types.WeaponRef
+}
+
+var (
+	ErrNoSnapshots = errors.New("no snapshots")
+	ErrNoWeapons   = errors.New("weapons not found")
+	ErrNoSchema    = errors.New("schema report not found")
+)
+
 type WeaponsService struct {
-	upserter   WeaponsUpserter
+	stager     WeaponsStager
 	provider   WeaponsProvider
 	aggregator WeaponsAggregator
 	updater    VersionUpdater
+	recorder   HistoryRecorder
+	history    HistoryProvider
+	suggester  Suggester
+	schema     SchemaRecorder
+	mu         sync.Mutex
 }
 
 func New(
-	upserter WeaponsUpserter,
+	stager WeaponsStager,
 	provider WeaponsProvider,
 	aggregator WeaponsAggregator,
 	updater VersionUpdater,
+	recorder HistoryRecorder,
+	history HistoryProvider,
+	suggester Suggester,
+	schema SchemaRecorder,
 ) *WeaponsService {
 	return &WeaponsService{
-		upserter:   upserter,
+		stager:     stager,
 		provider:   provider,
 		aggregator: aggregator,
 		updater:    updater,
+		recorder:   recorder,
+		history:    history,
+		suggester:  suggester,
+		schema:     schema,
 	}
 }
 
-func (s *WeaponsService) UpdateWeapons(ctx context.Context) error {
+func (s *WeaponsService) UpdateWeapons(ctx context.Context, req types.UpdateRequest) (types.UpdateResult, error) {
 	log := logger.FromContext(ctx, logger.Service)
 
-	weapons, err := s.aggregator.AggregateWeapons(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := time.Now()
+
+	ctx, stats := csvreader.WithStats(ctx)
+
+	weapons, reports, err := s.aggregator.AggregateWeapons(ctx, req)
 	if err != nil {
 		log.Error("AggregateWeapons error",
 			zap.Error(err),
+			zap.Int("retries", stats.Retries()),
 		)
-		return fmt.Errorf("failed to aggregate weapons: %w", err)
+		return types.UpdateResult{}, fmt.Errorf("failed to aggregate weapons: %w", err)
+	}
+
+	var failed []string
+	for _, report := range reports {
+		if report.Status == types.CategoryStatusFailed {
+			log.Warn("Category update failed",
+				zap.String("category", report.Category),
+				zap.String("error", report.Error),
+			)
+			failed = append(failed, report.Category)
+		}
 	}
 
-	if err := s.upserter.UpsertWeapons(ctx, weapons); err != nil {
-		log.Error("UpsertWeapons error",
+	stored, err := s.provider.Weapons(ctx, types.WeaponsQuery{IncludeRetired: true})
+	if err != nil {
+		log.Error("Weapons error",
 			zap.Error(err),
 		)
-		return err
+		return types.UpdateResult{}, fmt.Errorf("failed to get stored weapons: %w", err)
+	}
+
+	merges := weaponresolver.Resolve(weapons, stored, parsedCategories(reports))
+
+	countUnchanged(reports, stored)
+
+	version, err := s.updater.LatestVersion(ctx)
+	if err != nil {
+		log.Error("LatestVersion error",
+			zap.Error(err),
+		)
+		return types.UpdateResult{}, err
 	}
 
-	if err := s.updater.UpdateVersion(ctx); err != nil {
-		log.Error("UpdateVersion error",
+	previous, err := s.recorder.Version(ctx)
+	if err != nil && !errors.Is(err, mongodb.ErrNoVersion) {
+		log.Error("Version error",
 			zap.Error(err),
 		)
+		return types.UpdateResult{}, fmt.Errorf("failed to get previous ingest: %w", err)
+	}
+
+	record := newIngest(version, req, previous, reports)
+
+	if unchangedOnly(reports) {
+		if err := s.recordUnchanged(ctx, record, start, req, reports); err != nil {
+			log.Error("recordUnchanged error",
+				zap.Error(err),
+			)
+			return types.UpdateResult{}, err
+		}
+
+		log.Info("Tables unchanged, ingest skipped",
+			zap.String("version", version.Version),
+			zap.Int("failed categories", len(failed)),
+		)
+
+		return types.UpdateResult{
+			Version:    version.Version,
+			Retries:    stats.Retries(),
+			Partial:    req.Partial,
+			Categories: reports,
+		}, nil
+	}
+
+	retired, err := s.ingest(ctx, record, start, req, weapons, reports, merges)
+	if err != nil {
+		log.Error("ingest error",
+			zap.Error(err),
+		)
+		return types.UpdateResult{}, err
+	}
+
+	s.aggregator.Commit(record.SourceHashes)
+
+	if err := s.RebuildSuggestions(ctx); err != nil {
+		log.Warn("RebuildSuggestions error",
+			zap.Error(err),
+		)
+	}
+
+	result := types.UpdateResult{
+		Version:    version.Version,
+		Weapons:    len(weapons),
+		Retries:    stats.Retries(),
+		Retired:    retired,
+		Merged:     len(merges),
+		Partial:    req.Partial,
+		Categories: reports,
+	}
+
+	log.Debug("UpdateWeapons complited",
+		zap.String("version", result.Version),
+		zap.Int("weapons", result.Weapons),
+		zap.Int("retries", result.Retries),
+		zap.Int("failed categories", len(failed)),
+	)
+
+	return result, nil
+}
+
+func (s *WeaponsService) ingest(ctx context.Context, record types.LastChange, start time.Time, req types.UpdateRequest, weapons []*types.Weapon, reports []types.CategoryReport, merges []types.WeaponMerge) (int, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	dataset, err := s.stager.Stage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stage dataset: %w", err)
+	}
+
+	promoted := false
+	defer func() {
+		if promoted {
+			return
+		}
+		if err := s.stager.Discard(context.WithoutCancel(ctx), dataset); err != nil {
+			log.Warn("Discard error",
+				zap.Error(err),
+			)
+		}
+	}()
+
+	if err := dataset.UpsertWeapons(ctx, weapons); err != nil {
+		return 0, err
+	}
+
+	retired, err := s.retireWeapons(ctx, dataset, record.Version, weapons, reports)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.recorder.RecordIngest(ctx, record); err != nil {
+		return 0, fmt.Errorf("failed to record ingest: %w", err)
+	}
+
+	if err := s.stager.Promote(ctx, dataset); err != nil {
+		return 0, fmt.Errorf("failed to promote dataset: %w", err)
+	}
+	promoted = true
+
+	if err := s.recordHistory(ctx, record.Version, req, weapons, reports, merges); err != nil {
+		return 0, err
+	}
+
+	if err := s.completeIngest(ctx, record, start); err != nil {
+		return 0, err
+	}
+
+	return retired, nil
+}
+
+func (s *WeaponsService) recordUnchanged(ctx context.Context, record types.LastChange, start time.Time, req types.UpdateRequest, reports []types.CategoryReport) error {
+	if err := s.recordHistory(ctx, record.Version, req, nil, reports, nil); err != nil {
+		return err
+	}
+
+	if err := s.recorder.RecordIngest(ctx, record); err != nil {
+		return fmt.Errorf("failed to record ingest: %w", err)
+	}
+
+	return s.completeIngest(ctx, record, start)
+}
+
+func (s *WeaponsService) completeIngest(ctx context.Context, record types.LastChange, start time.Time) error {
+	record.IngestedAt = time.Now().UTC()
+	record.DurationMs = time.Since(start).Milliseconds()
+
+	if err := s.recorder.CompleteIngest(ctx, record); err != nil {
+		return fmt.Errorf("failed to complete ingest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WeaponsService) recordHistory(ctx context.Context, version string, req types.UpdateRequest, weapons []*types.Weapon, reports []types.CategoryReport, merges []types.WeaponMerge) error {
+	log := logger.FromContext(ctx, logger.Service)
+
+	if err := s.recordMerges(ctx, version, merges); err != nil {
 		return err
 	}
 
-	log.Debug("UpdateWeapons complited")
+	snapshots := weapons
+	if len(req.Categories) > 0 || slices.ContainsFunc(reports, func(report types.CategoryReport) bool {
+		return report.Status != types.CategoryStatusOK
+	}) {
+		kept, err := s.keptWeapons(ctx, reports)
+		if err != nil {
+			return fmt.Errorf("failed to get kept weapons: %w", err)
+		}
+		snapshots = append(slices.Clone(weapons), kept...)
+	}
+
+	if err := s.recorder.RecordSnapshots(ctx, version, snapshots); err != nil {
+		return fmt.Errorf("failed to record snapshots: %w", err)
+	}
+
+	if err := s.recordSchemaReport(ctx, version, reports); err != nil {
+		log.Warn("recordSchemaReport error",
+			zap.Error(err),
+		)
+	}
+
+	return nil
+}
+
+func newIngest(version types.VersionInfo, req types.UpdateRequest, previous types.LastChange, reports []types.CategoryReport) types.LastChange {
+	ingest := types.LastChange{
+		ID:           uuid.NewString(),
+		VersionInfo:  version,
+		Trigger:      req.Trigger,
+		Partial:      req.Partial,
+		Categories:   make(map[string]int),
+		SourceHashes: make(map[string]string),
+		Pending:      true,
+	}
+
+	for _, report := range reports {
+		if report.Status == types.CategoryStatusFailed {
+			continue
+		}
+		ingest.Categories[report.Category] = report.Weapons
+		if report.Hash != "" {
+			ingest.SourceHashes[report.Category] = report.Hash
+		}
+	}
+
+	for category, weapons := range previous.Categories {
+		if _, ok := ingest.Categories[category]; !ok {
+			ingest.Categories[category] = weapons
+		}
+	}
+
+	for category, hash := range previous.SourceHashes {
+		if _, ok := ingest.SourceHashes[category]; !ok {
+			ingest.SourceHashes[category] = hash
+		}
+	}
+
+	for _, weapons := range ingest.Categories {
+		ingest.Weapons += weapons
+	}
+
+	return ingest
+}
+
+func (s *WeaponsService) recordMerges(ctx context.Context, version string, merges []types.WeaponMerge) error {
+	log := logger.FromContext(ctx, logger.Service)
+
+	if len(merges) == 0 {
+		return nil
+	}
+
+	mergedAt := time.Now().UTC()
+
+	for i := range merges {
+		merges[i].Version = version
+		merges[i].MergedAt = mergedAt
+
+		log.Info("Weapon identity merged",
+			zap.String("id", merges[i].WeaponID),
+			zap.String("alias", merges[i].Alias),
+			zap.String("from", merges[i].FromName),
+			zap.String("to", merges[i].ToName),
+			zap.Strings("reasons", merges[i].Reasons),
+		)
+	}
+
+	if err := s.recorder.RecordMerges(ctx, merges); err != nil {
+		return fmt.Errorf("failed to record merges: %w", err)
+	}
+
+	return nil
+}
+
+func countUnchanged(reports []types.CategoryReport, stored []*types.Weapon) {
+	counts := make(map[string]int)
+	for _, weapon := range stored {
+		if !weapon.Retired {
+			counts[weapon.Category]++
+		}
+	}
+
+	for i := range reports {
+		if reports[i].Status == types.CategoryStatusUnchanged {
+			reports[i].Weapons = counts[reports[i].Category]
+		}
+	}
+}
+
+func unchangedOnly(reports []types.CategoryReport) bool {
+	unchanged := false
+
+	for _, report := range reports {
+		switch report.Status {
+		case types.CategoryStatusOK:
+			return false
+		case types.CategoryStatusUnchanged:
+			unchanged = true
+		}
+	}
+
+	return unchanged
+}
+
+func parsedCategories(reports []types.CategoryReport) []string {
+	categories := make([]string, 0, len(reports))
+
+	for _, report := range reports {
+		if report.Status == types.CategoryStatusOK && report.Weapons > 0 {
+			categories = append(categories, report.Category)
+		}
+	}
+
+	return categories
+}
+
+func (s *WeaponsService) retireWeapons(ctx context.Context, dataset mongodb.Dataset, version string, weapons []*types.Weapon, reports []types.CategoryReport) (int, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	ids := make(map[string][]string)
+	for _, weapon := range weapons {
+		ids[weapon.Category] = append(ids[weapon.Category], weapon.ID)
+	}
+
+	total := 0
+
+	for _, category := range parsedCategories(reports) {
+		retired, err := dataset.RetireWeapons(ctx, category, ids[category], version)
+		if err != nil {
+			return total, fmt.Errorf("failed to retire weapons in %s: %w", category, err)
+		}
+
+		if retired > 0 {
+			log.Info("Weapons retired",
+				zap.String("category", category),
+				zap.String("version", version),
+				zap.Int("retired", retired),
+			)
+		}
+
+		total += retired
+	}
+
+	return total, nil
+}
+
+func (s *WeaponsService) recordSchemaReport(ctx context.Context, version string, reports []types.CategoryReport) error {
+	log := logger.FromContext(ctx, logger.Service)
+
+	previous, err := s.schema.SchemaReport(ctx, "")
+	if err != nil && !errors.Is(err, mongodb.ErrNoSchema) {
+		return fmt.Errorf("failed to get previous schema report: %w", err)
+	}
+
+	report := weaponmapper.SchemaReport(version, reports, previous)
+
+	for _, category := range report.Categories {
+		if len(category.Unknown) > 0 || len(category.Missing) > 0 {
+			log.Warn("Schema drift detected",
+				zap.String("category", category.Category),
+				zap.Strings("unknown", category.Unknown),
+				zap.Strings("missing", category.Missing),
+			)
+		}
+	}
+
+	if err := s.schema.RecordSchemaReport(ctx, report); err != nil {
+		return fmt.Errorf("failed to record schema report: %w", err)
+	}
 
 	return nil
 }
 
-func (s *WeaponsService) GetWeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error) {
+func (s *WeaponsService) GetSchemaReport(ctx context.Context, version string) (types.SchemaReport, error) {
 	log := logger.FromContext(ctx, logger.Service)
 
-	weapons, err := s.provider.WeaponsByCategory(ctx, category)
+	report, err := s.schema.SchemaReport(ctx, version)
 	if err != nil {
-		log.Error("WeaponsByCategory error",
+		if errors.Is(err, mongodb.ErrNoSchema) {
+			log.Warn("No schema report",
+				zap.String("version", version),
+			)
+			return types.SchemaReport{}, ErrNoSchema
+		}
+		log.Error("SchemaReport error",
 			zap.Error(err),
-			zap.String("category", category),
+			zap.String("version", version),
 		)
+		return types.SchemaReport{}, err
+	}
+
+	return report, nil
+}
+
+func (s *WeaponsService) keptWeapons(ctx context.Context, reports []types.CategoryReport) ([]*types.Weapon, error) {
+	updated := make(map[string]struct{}, len(reports))
+	for _, report := range reports {
+		if report.Status == types.CategoryStatusOK {
+			updated[report.Category] = struct{}{}
+		}
+	}
+
+	stored, err := s.provider.Weapons(ctx, types.WeaponsQuery{})
+	if err != nil {
 		return nil, err
 	}
 
-	log.Debug("GetWeaponsByCategory complited",
-		zap.String("category", category),
+	kept := make([]*types.Weapon, 0, len(stored))
+	for _, weapon := range stored {
+		if _, ok := updated[weapon.Category]; !ok {
+			kept = append(kept, weapon)
+		}
+	}
+
+	return kept, nil
+}
+
+func (s *WeaponsService) RebuildSuggestions(ctx context.Context) error {
+	log := logger.FromContext(ctx, logger.Service)
+
+	weapons, err := s.provider.SearchableWeapons(ctx)
+	if err != nil {
+		log.Error("SearchableWeapons error",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	s.suggester.Rebuild(weapons)
+
+	log.Debug("RebuildSuggestions complited",
 		zap.Int("total weapons", len(weapons)),
 	)
 
-	return weapons, nil
+	return nil
 }
 
-func (s *WeaponsService) SearchWeapons(ctx context.Context, query string) ([]types.SearchResult, error) {
+func (s *WeaponsService) SuggestWeapons(ctx context.Context, query string, limit int) types.Suggestions {
 	log := logger.FromContext(ctx, logger.Service)
 
-	results, err := s.provider.WeaponsByName(ctx, query)
+	suggestions := s.suggester.Suggest(query, limit)
+
+	log.Debug("SuggestWeapons complited",
+		zap.String("query", query),
+		zap.Int("total suggestions", len(suggestions)),
+	)
+
+	return types.Suggestions{Suggestions: suggestions}
+}
+
+func (s *WeaponsService) GetWeapons(ctx context.Context, query types.WeaponsQuery) (types.Weapons, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	page := query.Page
+	query.Page = lookahead(page)
+
+	weapons, err := s.provider.Weapons(ctx, query)
 	if err != nil {
-		log.Error("WeaponsByName error",
+		log.Error("Weapons error",
 			zap.Error(err),
+			zap.Strings("categories", query.Categories),
+		)
+		return types.Weapons{}, err
+	}
+
+	weapons, next := trimPage(weapons, page)
+
+	log.Debug("GetWeapons complited",
+		zap.Strings("categories", query.Categories),
+		zap.Int("total weapons", len(weapons)),
+	)
+
+	return types.Weapons{Weapons: weapons, NextCursor: next}, nil
+}
+
+func (s *WeaponsService) GetWeaponByID(ctx context.Context, id string) (*types.Weapon, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	weapon, err := s.provider.WeaponByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongodb.ErrNoWeapon) {
+			log.Warn("No weapon",
+				zap.String("id", id),
+			)
+			return nil, fmt.Errorf("%w: %s", ErrNoWeapons, id)
+		}
+		log.Error("WeaponByID error",
+			zap.Error(err),
+			zap.String("id", id),
 		)
 		return nil, err
 	}
 
+	log.Debug("GetWeaponByID complited",
+		zap.String("id", id),
+	)
+
+	return weapon, nil
+}
+
+func (s *WeaponsService) SearchWeapons(ctx context.Context, query string, page types.Page) (types.SearchResults, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	weapons, err := s.provider.SearchableWeapons(ctx)
+	if err != nil {
+		log.Error("SearchableWeapons error",
+			zap.Error(err),
+		)
+		return types.SearchResults{}, err
+	}
+
+	results := weaponsearch.Search(query, weapons)
+	results = results[min(page.Offset, len(results)):]
+
+	results, next := trimPage(results, page)
+
 	log.Debug("SearchWeapons complited",
 		zap.String("query", query),
 		zap.Int("total overlaps", len(results)),
 	)
 
-	return results, nil
+	return types.SearchResults{Results: results, NextCursor: next}, nil
+}
+
+func (s *WeaponsService) GetWeaponHistory(ctx context.Context, id string) (types.WeaponHistory, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	snapshots, err := s.history.WeaponHistory(ctx, id)
+	if err != nil {
+		log.Error("WeaponHistory error",
+			zap.Error(err),
+			zap.String("id", id),
+		)
+		return types.WeaponHistory{}, err
+	}
+
+	history := types.WeaponHistory{ID: id}
+
+	var prev *types.Weapon
+
+	for _, snapshot := range snapshots {
+		changes := weaponfields.Diff(prev, snapshot.Weapon)
+		prev = snapshot.Weapon
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		history.Name = snapshot.Weapon.Name
+		history.Category = snapshot.Weapon.Category
+		history.History = append(history.History, types.HistoryEntry{
+			Version:    snapshot.Version,
+			RecordedAt: snapshot.RecordedAt,
+			Changes:    changes,
+		})
+	}
+
+	log.Debug("GetWeaponHistory complited",
+		zap.String("id", id),
+		zap.Int("total entries", len(history.History)),
+	)
+
+	return history, nil
+}
+
+func (s *WeaponsService) DiffVersions(ctx context.Context, from, to, category string) (types.VersionDiff, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	prev, err := s.snapshotsByVersion(ctx, from, category)
+	if err != nil {
+		log.Error("SnapshotsByVersion error",
+			zap.Error(err),
+			zap.String("version", from),
+		)
+		return types.VersionDiff{}, err
+	}
+
+	curr, err := s.snapshotsByVersion(ctx, to, category)
+	if err != nil {
+		log.Error("SnapshotsByVersion error",
+			zap.Error(err),
+			zap.String("version", to),
+		)
+		return types.VersionDiff{}, err
+	}
+
+	diff := types.VersionDiff{
+		From:     from,
+		To:       to,
+		Category: category,
+		Added:    []types.WeaponRef{},
+		Removed:  []types.WeaponRef{},
+		Changed:  []types.WeaponChanges{},
+	}
+
+	for id, weapon := range curr {
+		old, ok := prev[id]
+		if !ok {
+			diff.Added = append(diff.Added, weaponRef(weapon))
+			continue
+		}
+
+		if changes := weaponfields.Diff(old, weapon); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, types.WeaponChanges{
+				WeaponRef: weaponRef(weapon),
+				Changes:   changes,
+			})
+		}
+	}
+
+	for id, weapon := range prev {
+		if _, ok := curr[id]; !ok {
+			diff.Removed = append(diff.Removed, weaponRef(weapon))
+		}
+	}
+
+	sortWeaponRefs(diff.Added)
+	sortWeaponRefs(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return lessWeaponRef(diff.Changed[i].WeaponRef, diff.Changed[j].WeaponRef)
+	})
+
+	log.Debug("DiffVersions complited",
+		zap.String("from", from),
+		zap.String("to", to),
+		zap.Int("added", len(diff.Added)),
+		zap.Int("removed", len(diff.Removed)),
+		zap.Int("changed", len(diff.Changed)),
+	)
+
+	return diff, nil
+}
+
+func (s *WeaponsService) CompareWeapons(ctx context.Context, ids []string) (types.Comparison, error) {
+	log := logger.FromContext(ctx, logger.Service)
+
+	weapons, err := s.provider.WeaponsByIDs(ctx, ids)
+	if err != nil {
+		log.Error("WeaponsByIDs error",
+			zap.Error(err),
+		)
+		return types.Comparison{}, err
+	}
+
+	byID := make(map[string]*types.Weapon, len(weapons))
+	for _, weapon := range weapons {
+		for _, alias := range weapon.Aliases {
+			if _, ok := byID[alias]; !ok {
+				byID[alias] = weapon
+			}
+		}
+	}
+	for _, weapon := range weapons {
+		byID[weapon.ID] = weapon
+	}
+
+	ordered := make([]*types.Weapon, 0, len(ids))
+	var missing []string
+
+	for _, id := range ids {
+		weapon, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		ordered = append(ordered, weapon)
+	}
+
+	if len(missing) > 0 {
+		log.Warn("Weapons not found",
+			zap.Strings("ids", missing),
+		)
+		return types.Comparison{}, fmt.Errorf("%w: %s", ErrNoWeapons, strings.Join(missing, ", "))
+	}
+
+	comparison := weaponcomparator.Compare(ordered)
+
+	log.Debug("CompareWeapons complited",
+		zap.Strings("ids", ids),
+		zap.Int("total fields", len(comparison.Fields)),
+	)
+
+	return comparison, nil
+}
+
+func (s *WeaponsService) snapshotsByVersion(ctx context.Context, version, category string) (map[string]*types.Weapon, error) {
+	snapshots, err := s.history.SnapshotsByVersion(ctx, version, category)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w for version %s", ErrNoSnapshots, version)
+	}
+
+	weapons := make(map[string]*types.Weapon, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		weapons[snapshot.WeaponID] = snapshot.Weapon
+	}
+
+	return weapons, nil
+}
+
+func weaponRef(weapon *types.Weapon) types.WeaponRef {
+	return types.WeaponRef{
+		ID:       weapon.ID,
+		Name:     weapon.Name,
+		Category: weapon.Category,
+	}
+}
+
+func sortWeaponRefs(weapons []types.WeaponRef) {
+	sort.Slice(weapons, func(i, j int) bool {
+		return lessWeaponRef(weapons[i], weapons[j])
+	})
+}
+
+func lessWeaponRef(a, b types.WeaponRef) bool {
+	if a.Category != b.Category {
+		return a.Category < b.Category
+	}
+	return a.Name < b.Name
+}
+
+func lookahead(page types.Page) types.Page {
+	if page.Limit > 0 {
+		page.Limit++
+	}
+	return page
+}
+
+func trimPage[T any](items []T, page types.Page) ([]T, string) {
+	if page.Limit <= 0 || len(items) <= page.Limit {
+		return items, ""
+	}
+	return items[:page.Limit], cursor.Encode(page.Offset+page.Limit, page.Limit)
 }
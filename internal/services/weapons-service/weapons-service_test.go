@@ -4,13 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/erknas/wt-guided-weapons/internal/lib/cursor"
+	weaponid "github.com/erknas/wt-guided-weapons/internal/lib/weapon-id"
+	weaponsparser "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapon-parser"
+	weaponsaggregator "github.com/erknas/wt-guided-weapons/internal/services/weapons-service/weapons-aggregator"
+	"github.com/erknas/wt-guided-weapons/internal/storage/mongodb"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 type mockWeaponsUpserter struct {
@@ -21,6 +29,10 @@ type mockWeaponsProvider struct {
 	mock.Mock
 }
 
+type mockSuggester struct {
+	mock.Mock
+}
+
 type mockWeaponsAggregator struct {
 	mock.Mock
 }
@@ -29,31 +41,136 @@ type mockVersionUpdater struct {
 	mock.Mock
 }
 
+type mockHistoryRecorder struct {
+	mock.Mock
+}
+
+type mockHistoryProvider struct {
+	mock.Mock
+}
+
+type stubReader struct {
+	data map[string][][]string
+	errs map[string]error
+}
+
+type stubMapper struct{}
+
+func (r *stubReader) Read(ctx context.Context, url string) ([][]string, error) {
+	if err := r.errs[url]; err != nil {
+		return nil, err
+	}
+	return r.data[url], nil
+}
+
+func (stubMapper) Map(data [][]string, category string, weaponIdx int) (*types.Weapon, error) {
+	return &types.Weapon{Category: category, Name: data[0][weaponIdx]}, nil
+}
+
 func (m *mockWeaponsUpserter) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) error {
 	args := m.Called(ctx, weapons)
 	return args.Error(0)
 }
 
-func (m *mockWeaponsProvider) WeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error) {
-	args := m.Called(ctx, category)
-	return args.Get(0).([]*types.Weapon), args.Error(1)
+func (m *mockWeaponsUpserter) Stage(ctx context.Context) (mongodb.Dataset, error) {
+	args := m.Called(ctx)
+	return m, args.Error(0)
+}
+
+func (m *mockWeaponsUpserter) Promote(ctx context.Context, dataset mongodb.Dataset) error {
+	args := m.Called(ctx, dataset)
+	return args.Error(0)
+}
+
+func (m *mockWeaponsUpserter) Discard(ctx context.Context, dataset mongodb.Dataset) error {
+	args := m.Called(ctx, dataset)
+	return args.Error(0)
+}
+
+func (m *mockWeaponsUpserter) RetireWeapons(ctx context.Context, category string, keep []string, version string) (int, error) {
+	args := m.Called(ctx, category, keep, version)
+	return args.Int(0), args.Error(1)
 }
 
-func (m *mockWeaponsProvider) WeaponsByName(ctx context.Context, query string) ([]types.SearchResult, error) {
+func (m *mockWeaponsProvider) Weapons(ctx context.Context, query types.WeaponsQuery) ([]*types.Weapon, error) {
 	args := m.Called(ctx, query)
-	return args.Get(0).([]types.SearchResult), args.Error(1)
+	return args.Get(0).([]*types.Weapon), args.Error(1)
+}
+
+func (m *mockSuggester) Rebuild(weapons []*types.Weapon) {
+	m.Called(weapons)
 }
 
-func (m *mockWeaponsAggregator) AggregateWeapons(ctx context.Context) ([]*types.Weapon, error) {
+func (m *mockSuggester) Suggest(query string, limit int) []types.WeaponRef {
+	args := m.Called(query, limit)
+	return args.Get(0).([]types.WeaponRef)
+}
+
+func (m *mockWeaponsProvider) SearchableWeapons(ctx context.Context) ([]*types.Weapon, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]*types.Weapon), args.Error(1)
 }
 
-func (m *mockVersionUpdater) UpdateVersion(ctx context.Context) error {
+func (m *mockWeaponsProvider) WeaponByID(ctx context.Context, id string) (*types.Weapon, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*types.Weapon), args.Error(1)
+}
+
+func (m *mockWeaponsProvider) WeaponsByIDs(ctx context.Context, ids []string) ([]*types.Weapon, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*types.Weapon), args.Error(1)
+}
+
+func (m *mockWeaponsAggregator) AggregateWeapons(ctx context.Context, req types.UpdateRequest) ([]*types.Weapon, []types.CategoryReport, error) {
+	args := m.Called(ctx, req)
+	reports, _ := args.Get(1).([]types.CategoryReport)
+	return args.Get(0).([]*types.Weapon), reports, args.Error(2)
+}
+
+func (m *mockWeaponsAggregator) Commit(hashes map[string]string) {
+	m.Called(hashes)
+}
+
+func (m *mockVersionUpdater) LatestVersion(ctx context.Context) (types.VersionInfo, error) {
 	args := m.Called(ctx)
+	return args.Get(0).(types.VersionInfo), args.Error(1)
+}
+
+func (m *mockHistoryRecorder) RecordSnapshots(ctx context.Context, version string, weapons []*types.Weapon) error {
+	args := m.Called(ctx, version, weapons)
+	return args.Error(0)
+}
+
+func (m *mockHistoryRecorder) RecordMerges(ctx context.Context, merges []types.WeaponMerge) error {
+	args := m.Called(ctx, merges)
+	return args.Error(0)
+}
+
+func (m *mockHistoryRecorder) RecordIngest(ctx context.Context, ingest types.LastChange) error {
+	args := m.Called(ctx, ingest)
+	return args.Error(0)
+}
+
+func (m *mockHistoryRecorder) CompleteIngest(ctx context.Context, ingest types.LastChange) error {
+	args := m.Called(ctx, ingest)
 	return args.Error(0)
 }
 
+func (m *mockHistoryRecorder) Version(ctx context.Context) (types.LastChange, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(types.LastChange), args.Error(1)
+}
+
+func (m *mockHistoryProvider) WeaponHistory(ctx context.Context, id string) ([]types.WeaponSnapshot, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]types.WeaponSnapshot), args.Error(1)
+}
+
+func (m *mockHistoryProvider) SnapshotsByVersion(ctx context.Context, version, category string) ([]types.WeaponSnapshot, error) {
+	args := m.Called(ctx, version, category)
+	return args.Get(0).([]types.WeaponSnapshot), args.Error(1)
+}
+
 func TestWeaponsService_UpdateWeapons(t *testing.T) {
 	weapons := []*types.Weapon{
 		{Category: "sam-ir", Name: "9M39 Igla"},
@@ -62,9 +179,11 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 		{Category: "sam-ir", Name: "HN-6"},
 	}
 
+	version := types.VersionInfo{Version: "2.47.0.114"}
+
 	tests := []struct {
 		name        string
-		mocks       func(*mockWeaponsAggregator, *mockWeaponsUpserter, *mockVersionUpdater)
+		mocks       func(*mockWeaponsAggregator, *mockWeaponsUpserter, *mockVersionUpdater, *mockHistoryRecorder)
 		ctx         func() context.Context
 		wantErr     bool
 		containsErr string
@@ -72,17 +191,19 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 	}{
 		{
 			name: "success",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return(weapons, nil)
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
 				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
-				mvu.On("UpdateVersion", mock.Anything).Return(nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mhr.On("RecordSnapshots", mock.Anything, version.Version, weapons).Return(nil)
 			},
 			wantErr: false,
 		},
 		{
 			name: "fail Upsert error",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return(weapons, nil)
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
 				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(errors.New("failed to upsert documents"))
 			},
 			wantErr:     true,
@@ -93,8 +214,8 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 		},
 		{
 			name: "fail Aggregate error",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return([]*types.Weapon{}, errors.New("failed to parse table"))
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return([]*types.Weapon{}, nil, errors.New("failed to parse table"))
 			},
 			wantErr:     true,
 			containsErr: "failed to aggregate weapons",
@@ -103,11 +224,10 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 			},
 		},
 		{
-			name: "fail UpdateVersion error",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return(weapons, nil)
-				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
-				mvu.On("UpdateVersion", mock.Anything).Return(errors.New("failed to update version"))
+			name: "fail LatestVersion error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(types.VersionInfo{}, errors.New("failed to update version"))
 			},
 			wantErr:     true,
 			containsErr: "failed to update version",
@@ -115,10 +235,76 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 				assert.Contains(t, err.Error(), "failed to update version")
 			},
 		},
+		{
+			name: "fail Version error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mhr.On("Version", mock.Anything).Return(types.LastChange{}, errors.New("failed to find document"))
+			},
+			wantErr:     true,
+			containsErr: "failed to get previous ingest",
+		},
+		{
+			name: "fail Promote error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
+				mwu.On("Promote", mock.Anything, mwu).Return(mongodb.ErrInvalidDataset)
+				mwu.On("Discard", mock.Anything, mwu).Return(nil)
+			},
+			wantErr:     true,
+			containsErr: "failed to promote dataset",
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, mongodb.ErrInvalidDataset)
+			},
+		},
+		{
+			name: "fail RecordIngest error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mhr.On("RecordIngest", mock.Anything, mock.Anything).Return(errors.New("failed to insert document"))
+				mwu.On("Discard", mock.Anything, mwu).Return(nil)
+			},
+			wantErr:     true,
+			containsErr: "failed to record ingest",
+		},
+		{
+			name: "fail CompleteIngest error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mhr.On("RecordSnapshots", mock.Anything, version.Version, weapons).Return(nil)
+				mhr.On("RecordIngest", mock.Anything, mock.Anything).Return(nil)
+				mwu.On("Promote", mock.Anything, mwu).Return(nil)
+				mhr.On("CompleteIngest", mock.Anything, mock.Anything).Return(mongodb.ErrNoVersion)
+			},
+			wantErr:     true,
+			containsErr: "failed to complete ingest",
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, mongodb.ErrNoVersion)
+			},
+		},
+		{
+			name: "fail RecordSnapshots error",
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mwu.On("UpsertWeapons", mock.Anything, weapons).Return(nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
+				mhr.On("RecordSnapshots", mock.Anything, version.Version, weapons).Return(errors.New("failed to record snapshots"))
+				mwu.On("Promote", mock.Anything, mwu).Return(nil)
+			},
+			wantErr:     true,
+			containsErr: "failed to record snapshots",
+		},
 		{
 			name: "fail Aggregate context cancelled",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return([]*types.Weapon{}, fmt.Errorf("failed to parse table: %w", context.Canceled))
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return([]*types.Weapon{}, nil, fmt.Errorf("failed to parse table: %w", context.Canceled))
 			},
 			ctx: func() context.Context {
 				ctx, cancel := context.WithCancel(context.Background())
@@ -133,8 +319,8 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 		},
 		{
 			name: "fail Aggregate context timeout",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return([]*types.Weapon{}, fmt.Errorf("failed to parse table: %w", context.DeadlineExceeded))
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return([]*types.Weapon{}, nil, fmt.Errorf("failed to parse table: %w", context.DeadlineExceeded))
 			},
 			ctx: func() context.Context {
 				ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
@@ -150,8 +336,9 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 		},
 		{
 			name: "fail Upsert context cancelled",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return(weapons, nil)
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
 				mwu.On("UpsertWeapons", mock.Anything, mock.Anything).Return(fmt.Errorf("failed to upsert documents: %w", context.Canceled))
 			},
 			ctx: func() context.Context {
@@ -167,8 +354,9 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 		},
 		{
 			name: "fail Upsert context timeout",
-			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater) {
-				mwa.On("AggregateWeapons", mock.Anything).Return(weapons, nil)
+			mocks: func(mwa *mockWeaponsAggregator, mwu *mockWeaponsUpserter, mvu *mockVersionUpdater, mhr *mockHistoryRecorder) {
+				mwa.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(weapons, nil, nil)
+				mvu.On("LatestVersion", mock.Anything).Return(version, nil)
 				mwu.On("UpsertWeapons", mock.Anything, mock.Anything).Return(fmt.Errorf("failed to upsert documents: %w", context.DeadlineExceeded))
 			},
 			ctx: func() context.Context {
@@ -190,12 +378,33 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 			mockWeaponsAggregator := new(mockWeaponsAggregator)
 			mockWeaponsUpserter := new(mockWeaponsUpserter)
 			mockVersionUpdater := new(mockVersionUpdater)
-			tt.mocks(mockWeaponsAggregator, mockWeaponsUpserter, mockVersionUpdater)
+			mockHistoryRecorder := new(mockHistoryRecorder)
+			mockWeaponsProvider := new(mockWeaponsProvider)
+			mockSuggester := new(mockSuggester)
+			tt.mocks(mockWeaponsAggregator, mockWeaponsUpserter, mockVersionUpdater, mockHistoryRecorder)
+
+			mockWeaponsAggregator.On("Commit", mock.Anything).Maybe()
+
+			mockWeaponsUpserter.On("Stage", mock.Anything).Return(nil).Maybe()
+			mockWeaponsUpserter.On("Promote", mock.Anything, mockWeaponsUpserter).Return(nil).Maybe()
+			mockWeaponsUpserter.On("Discard", mock.Anything, mockWeaponsUpserter).Return(nil).Maybe()
+
+			mockHistoryRecorder.On("RecordIngest", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockHistoryRecorder.On("CompleteIngest", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockHistoryRecorder.On("Version", mock.Anything).Return(types.LastChange{}, mongodb.ErrNoVersion).Maybe()
+
+			mockWeaponsProvider.On("Weapons", mock.Anything, types.WeaponsQuery{IncludeRetired: true}).Return([]*types.Weapon{}, nil).Maybe()
+			mockWeaponsProvider.On("SearchableWeapons", mock.Anything).Return(weapons, nil).Maybe()
+			mockSuggester.On("Rebuild", weapons).Maybe()
 
 			service := &WeaponsService{
 				aggregator: mockWeaponsAggregator,
-				upserter:   mockWeaponsUpserter,
+				stager:     mockWeaponsUpserter,
+				provider:   mockWeaponsProvider,
 				updater:    mockVersionUpdater,
+				recorder:   mockHistoryRecorder,
+				suggester:  mockSuggester,
+				schema:     mongodb.NewMockDB(),
 			}
 
 			ctx := context.Background()
@@ -203,7 +412,7 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 				ctx = tt.ctx()
 			}
 
-			err := service.UpdateWeapons(ctx)
+			result, err := service.UpdateWeapons(ctx, types.UpdateRequest{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -211,17 +420,521 @@ func TestWeaponsService_UpdateWeapons(t *testing.T) {
 				if tt.checkErr != nil {
 					tt.checkErr(t, err)
 				}
+				mockWeaponsAggregator.AssertNotCalled(t, "Commit", mock.Anything)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, types.UpdateResult{Version: version.Version, Weapons: len(weapons)}, result)
+				mockSuggester.AssertCalled(t, "Rebuild", weapons)
 			}
 
 			mockWeaponsAggregator.AssertExpectations(t)
 			mockWeaponsUpserter.AssertExpectations(t)
+			mockHistoryRecorder.AssertExpectations(t)
 		})
 	}
 }
 
-func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
+func TestWeaponsService_UpdateWeapons_Partial(t *testing.T) {
+	parsed := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+	}
+	kept := []*types.Weapon{
+		{Category: "aam-sarh", Name: "AIM-7C Sparrow"},
+	}
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 1},
+		{Category: "aam-sarh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockWeaponsUpserter := new(mockWeaponsUpserter)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockHistoryRecorder := new(mockHistoryRecorder)
+	mockWeaponsProvider := new(mockWeaponsProvider)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, types.UpdateRequest{Partial: true}).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockWeaponsUpserter.On("UpsertWeapons", mock.Anything, parsed).Return(nil)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockWeaponsUpserter.On("RetireWeapons", mock.Anything, "aam-arh", mock.Anything, version.Version).Return(0, nil)
+	mockWeaponsUpserter.On("Stage", mock.Anything).Return(nil)
+	mockWeaponsUpserter.On("Promote", mock.Anything, mockWeaponsUpserter).Return(nil)
+	mockWeaponsProvider.On("Weapons", mock.Anything, types.WeaponsQuery{IncludeRetired: true}).Return([]*types.Weapon{}, nil)
+	mockWeaponsProvider.On("Weapons", mock.Anything, types.WeaponsQuery{}).Return(append(slices.Clone(parsed), kept...), nil)
+	mockHistoryRecorder.On("RecordIngest", mock.Anything, mock.MatchedBy(func(ingest types.LastChange) bool {
+		return ingest.Version == version.Version && ingest.Partial && ingest.Pending && ingest.IngestedAt.IsZero() && maps.Equal(ingest.Categories, map[string]int{"aam-arh": 1})
+	})).Return(nil)
+	mockHistoryRecorder.On("CompleteIngest", mock.Anything, mock.MatchedBy(func(ingest types.LastChange) bool {
+		return ingest.ID != "" && !ingest.IngestedAt.IsZero()
+	})).Return(nil)
+	mockHistoryRecorder.On("Version", mock.Anything).Return(types.LastChange{}, mongodb.ErrNoVersion)
+	mockHistoryRecorder.On("RecordSnapshots", mock.Anything, version.Version, append(parsed, kept...)).Return(nil)
+	mockWeaponsProvider.On("SearchableWeapons", mock.Anything).Return(append(parsed, kept...), nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     mockWeaponsUpserter,
+		provider:   mockWeaponsProvider,
+		updater:    mockVersionUpdater,
+		recorder:   mockHistoryRecorder,
+		suggester:  mockSuggester,
+		schema:     mongodb.NewMockDB(),
+	}
+
+	result, err := service.UpdateWeapons(context.Background(), types.UpdateRequest{Partial: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.UpdateResult{
+		Version:    version.Version,
+		Weapons:    1,
+		Partial:    true,
+		Categories: reports,
+	}, result)
+
+	mockWeaponsAggregator.AssertExpectations(t)
+	mockWeaponsUpserter.AssertExpectations(t)
+	mockWeaponsProvider.AssertExpectations(t)
+	mockHistoryRecorder.AssertExpectations(t)
+}
+
+func TestWeaponsService_UpdateWeapons_Categories(t *testing.T) {
+	parsed := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+	}
+	stored := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+		{Category: "sam-ir-naval", Name: "Mistral"},
+	}
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 1},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+	req := types.UpdateRequest{Categories: []string{"aam-arh"}}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockWeaponsUpserter := new(mockWeaponsUpserter)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockHistoryRecorder := new(mockHistoryRecorder)
+	mockWeaponsProvider := new(mockWeaponsProvider)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, req).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockWeaponsUpserter.On("UpsertWeapons", mock.Anything, parsed).Return(nil)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockWeaponsUpserter.On("RetireWeapons", mock.Anything, "aam-arh", mock.Anything, version.Version).Return(1, nil)
+	mockWeaponsUpserter.On("Stage", mock.Anything).Return(nil)
+	mockWeaponsUpserter.On("Promote", mock.Anything, mockWeaponsUpserter).Return(nil)
+	mockWeaponsProvider.On("Weapons", mock.Anything, types.WeaponsQuery{IncludeRetired: true}).Return([]*types.Weapon{}, nil)
+	mockWeaponsProvider.On("Weapons", mock.Anything, types.WeaponsQuery{}).Return(stored, nil)
+	mockHistoryRecorder.On("RecordIngest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryRecorder.On("CompleteIngest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryRecorder.On("Version", mock.Anything).Return(types.LastChange{}, mongodb.ErrNoVersion)
+	mockHistoryRecorder.On("RecordSnapshots", mock.Anything, version.Version, []*types.Weapon{parsed[0], stored[1]}).Return(nil)
+	mockWeaponsProvider.On("SearchableWeapons", mock.Anything).Return(stored, nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     mockWeaponsUpserter,
+		provider:   mockWeaponsProvider,
+		updater:    mockVersionUpdater,
+		recorder:   mockHistoryRecorder,
+		suggester:  mockSuggester,
+		schema:     mongodb.NewMockDB(),
+	}
+
+	result, err := service.UpdateWeapons(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, reports, result.Categories)
+	assert.Equal(t, 1, result.Retired)
+
+	mockWeaponsAggregator.AssertExpectations(t)
+	mockWeaponsUpserter.AssertExpectations(t)
+	mockWeaponsProvider.AssertExpectations(t)
+	mockHistoryRecorder.AssertExpectations(t)
+}
+
+func TestWeaponsService_UpdateWeapons_Retire(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: weaponid.Generate(&types.Weapon{Category: "aam-arh", Name: "AAM-4"}), Category: "aam-arh", Name: "AAM-4"},
+		{ID: "2", Category: "aam-arh", Name: "AIM-54A"},
+		{ID: "3", Category: "aam-sarh", Name: "AIM-7C Sparrow"},
+	})
+
+	parsed := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+	}
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 1},
+		{Category: "aam-sarh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+	req := types.UpdateRequest{Partial: true}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockHistoryRecorder := new(mockHistoryRecorder)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, req).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockHistoryRecorder.On("RecordIngest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryRecorder.On("CompleteIngest", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryRecorder.On("Version", mock.Anything).Return(types.LastChange{}, mongodb.ErrNoVersion)
+	mockHistoryRecorder.On("RecordSnapshots", mock.Anything, version.Version, mock.Anything).Return(nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     db,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   mockHistoryRecorder,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	result, err := service.UpdateWeapons(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retired)
+
+	retired, err := db.WeaponByID(ctx, "2")
+	require.NoError(t, err)
+	assert.True(t, retired.Retired)
+	assert.Equal(t, version.Version, retired.RetiredIn)
+
+	kept, err := db.WeaponByID(ctx, "3")
+	require.NoError(t, err)
+	assert.False(t, kept.Retired)
+
+	i := slices.IndexFunc(mockHistoryRecorder.Calls, func(call mock.Call) bool {
+		return call.Method == "RecordSnapshots"
+	})
+	require.GreaterOrEqual(t, i, 0)
+
+	snapshots, ok := mockHistoryRecorder.Calls[i].Arguments.Get(2).([]*types.Weapon)
+	require.True(t, ok)
+	assert.Len(t, snapshots, 2)
+}
+
+func TestWeaponsService_UpdateWeapons_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "1", Category: "agm-salh", Name: "Kh-29L", AdditionalNotes: "Su-17M2", Mass: "657", Column: 4},
+	})
+
+	parsed := []*types.Weapon{
+		{Category: "agm-salh", Name: "Kh-29L", AdditionalNotes: "Su-17M2 and Su-22M3", Mass: "657", Column: 4},
+	}
+	reports := []types.CategoryReport{
+		{Category: "agm-salh", Status: types.CategoryStatusOK, Weapons: 1},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, types.UpdateRequest{}).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     db,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   db,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	result, err := service.UpdateWeapons(ctx, types.UpdateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Merged)
+	assert.Equal(t, 0, result.Retired)
+
+	weapon, err := db.WeaponByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Su-17M2 and Su-22M3", weapon.AdditionalNotes)
+	require.Len(t, weapon.Aliases, 1)
+
+	alias, err := db.WeaponByID(ctx, weapon.Aliases[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1", alias.ID)
+
+	history, err := db.WeaponHistory(ctx, "1")
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestWeaponsService_UpdateWeapons_Ingest(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+
+	_ = db.RecordIngest(ctx, types.LastChange{
+		VersionInfo:  types.VersionInfo{Version: "2.47.0.100"},
+		IngestedAt:   time.Now().Add(-time.Hour).UTC(),
+		Weapons:      8,
+		Categories:   map[string]int{"aam-arh": 1, "aam-sarh": 3, "agm-tv": 4},
+		SourceHashes: map[string]string{"aam-arh": "old", "aam-sarh": "def", "agm-tv": "ghi"},
+	})
+
+	parsed := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+		{Category: "aam-arh", Name: "AIM-54A"},
+	}
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "abc"},
+		{Category: "aam-sarh", Status: types.CategoryStatusFailed, Error: "failed to read CSV"},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+	req := types.UpdateRequest{Partial: true, Trigger: types.TriggerVersion}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, req).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     db,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   db,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	_, err := service.UpdateWeapons(ctx, req)
+	require.NoError(t, err)
+
+	ingest, err := db.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, version, ingest.VersionInfo)
+	assert.Equal(t, types.TriggerVersion, ingest.Trigger)
+	assert.True(t, ingest.Partial)
+	assert.Equal(t, 9, ingest.Weapons)
+	assert.Equal(t, map[string]int{"aam-arh": 2, "aam-sarh": 3, "agm-tv": 4}, ingest.Categories)
+	assert.Equal(t, map[string]string{"aam-arh": "abc", "aam-sarh": "def", "agm-tv": "ghi"}, ingest.SourceHashes)
+	assert.False(t, ingest.IngestedAt.IsZero())
+}
+
+func TestWeaponsService_UpdateWeapons_Unchanged(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "sarh-1", Category: "aam-sarh", Name: "AIM-7F"},
+		{ID: "sarh-2", Category: "aam-sarh", Name: "AIM-7E", Retired: true},
+	})
+
+	parsed := []*types.Weapon{
+		{Category: "aam-arh", Name: "AAM-4"},
+		{Category: "aam-arh", Name: "AIM-54A"},
+	}
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: "abc"},
+		{Category: "aam-sarh", Status: types.CategoryStatusUnchanged, Hash: "def"},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+	req := types.UpdateRequest{Trigger: types.TriggerVersion}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, req).Return(parsed, reports, nil)
+	mockWeaponsAggregator.On("Commit", mock.Anything)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     db,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   db,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	result, err := service.UpdateWeapons(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, result.Retired)
+
+	ingest, err := db.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"aam-arh": 2, "aam-sarh": 1}, ingest.Categories)
+	assert.Equal(t, map[string]string{"aam-arh": "abc", "aam-sarh": "def"}, ingest.SourceHashes)
+
+	weapon, err := db.WeaponByID(ctx, "sarh-1")
+	require.NoError(t, err)
+	assert.False(t, weapon.Retired)
+
+	snapshots, err := db.SnapshotsByVersion(ctx, version.Version, "aam-sarh")
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func TestWeaponsService_UpdateWeapons_AllUnchanged(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "arh-1", Category: "aam-arh", Name: "AAM-4"},
+		{ID: "sarh-1", Category: "aam-sarh", Name: "AIM-7F"},
+	})
+
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusUnchanged, Hash: "abc"},
+		{Category: "aam-sarh", Status: types.CategoryStatusUnchanged, Hash: "def"},
+	}
+	version := types.VersionInfo{Version: "2.47.0.114"}
+	req := types.UpdateRequest{Trigger: types.TriggerVersion}
+
+	mockWeaponsAggregator := new(mockWeaponsAggregator)
+	mockWeaponsUpserter := new(mockWeaponsUpserter)
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockSuggester := new(mockSuggester)
+
+	mockWeaponsAggregator.On("AggregateWeapons", mock.Anything, req).Return([]*types.Weapon{}, reports, nil)
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(version, nil)
+
+	service := &WeaponsService{
+		aggregator: mockWeaponsAggregator,
+		stager:     mockWeaponsUpserter,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   db,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	result, err := service.UpdateWeapons(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, types.UpdateResult{
+		Version: version.Version,
+		Categories: []types.CategoryReport{
+			{Category: "aam-arh", Status: types.CategoryStatusUnchanged, Weapons: 1, Hash: "abc"},
+			{Category: "aam-sarh", Status: types.CategoryStatusUnchanged, Weapons: 1, Hash: "def"},
+		},
+	}, result)
+
+	ingest, err := db.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, version, ingest.VersionInfo)
+	assert.Equal(t, map[string]string{"aam-arh": "abc", "aam-sarh": "def"}, ingest.SourceHashes)
+
+	snapshots, err := db.SnapshotsByVersion(ctx, version.Version, "")
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+
+	mockWeaponsUpserter.AssertNotCalled(t, "Stage", mock.Anything)
+	mockWeaponsAggregator.AssertNotCalled(t, "Commit", mock.Anything)
+	mockSuggester.AssertNotCalled(t, "Rebuild", mock.Anything)
+}
+
+func TestWeaponsService_UpdateWeapons_Retry(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+
+	tables := types.Tables{
+		"aam-arh":  {URL: "aam-arh-url", Priority: 10},
+		"aam-sarh": {URL: "aam-sarh-url"},
+	}
+	reader := &stubReader{
+		data: map[string][][]string{
+			"aam-arh-url":  {{"Name:", "AAM-4", "AIM-54A"}},
+			"aam-sarh-url": {{"Name:", "AIM-7F"}},
+		},
+		errs: map[string]error{
+			"aam-sarh-url": errors.New("failed to read CSV"),
+		},
+	}
+	aggregator := weaponsaggregator.New(tables, weaponsparser.New(reader, stubMapper{}), zap.NewNop(), 1)
+
+	mockVersionUpdater := new(mockVersionUpdater)
+	mockSuggester := new(mockSuggester)
+
+	mockVersionUpdater.On("LatestVersion", mock.Anything).Return(types.VersionInfo{Version: "2.47.0.114"}, nil)
+	mockSuggester.On("Rebuild", mock.Anything)
+
+	service := &WeaponsService{
+		aggregator: aggregator,
+		stager:     db,
+		provider:   db,
+		updater:    mockVersionUpdater,
+		recorder:   db,
+		suggester:  mockSuggester,
+		schema:     db,
+	}
+
+	_, err := service.UpdateWeapons(ctx, types.UpdateRequest{})
+	require.Error(t, err)
+
+	changed, err := aggregator.Changed(ctx, []string{"aam-arh"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	reader.errs = nil
+
+	result, err := service.UpdateWeapons(ctx, types.UpdateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Weapons)
+	assert.Equal(t, []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Weapons: 2, Hash: result.Categories[0].Hash, Labels: []string{"Name:"}},
+		{Category: "aam-sarh", Status: types.CategoryStatusOK, Weapons: 1, Hash: result.Categories[1].Hash, Labels: []string{"Name:"}},
+	}, result.Categories)
+
+	changed, err = aggregator.Changed(ctx, nil)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestWeaponsService_GetSchemaReport(t *testing.T) {
+	ctx := context.Background()
+
+	db := mongodb.NewMockDB()
+	service := &WeaponsService{schema: db}
+
+	_, err := service.GetSchemaReport(ctx, "")
+	assert.ErrorIs(t, err, ErrNoSchema)
+
+	reports := []types.CategoryReport{
+		{Category: "aam-arh", Status: types.CategoryStatusOK, Labels: []string{"Name:", "Band:"}},
+	}
+
+	err = service.recordSchemaReport(ctx, "2.47.0.1", reports)
+	require.NoError(t, err)
+
+	report, err := service.GetSchemaReport(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2.47.0.1", report.Version)
+	require.Len(t, report.Categories, 1)
+	assert.Equal(t, 1.0, report.Categories[0].Coverage)
+}
+
+func TestWeaponsService_GetWeapons(t *testing.T) {
 	weapons := []*types.Weapon{
 		{Category: "sam-ir", Name: "9M39 Igla"},
 		{Category: "sam-ir", Name: "AIM-9X"},
@@ -241,14 +954,14 @@ func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
 		name:     "success",
 		category: "sam-ir",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByCategory", mock.Anything, "sam-ir").Return(weapons, nil)
+			mwp.On("Weapons", mock.Anything, types.WeaponsQuery{Categories: []string{"sam-ir"}}).Return(weapons, nil)
 		},
 		wantErr: false,
 	}, {
-		name:     "fail ByCategory error",
+		name:     "fail Weapons error",
 		category: "sam-ir",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByCategory", mock.Anything, "sam-ir").Return([]*types.Weapon{}, errors.New("failed to find documents"))
+			mwp.On("Weapons", mock.Anything, types.WeaponsQuery{Categories: []string{"sam-ir"}}).Return([]*types.Weapon{}, errors.New("failed to find documents"))
 		},
 		wantErr:     true,
 		containsErr: "failed to find documents",
@@ -256,10 +969,10 @@ func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
 			assert.Contains(t, err.Error(), "failed to find documents")
 		},
 	}, {
-		name:     "fail ByCategory context cancelled",
+		name:     "fail Weapons context cancelled",
 		category: "sam-ir",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByCategory", mock.Anything, "sam-ir").Return([]*types.Weapon{}, context.Canceled)
+			mwp.On("Weapons", mock.Anything, types.WeaponsQuery{Categories: []string{"sam-ir"}}).Return([]*types.Weapon{}, context.Canceled)
 		},
 		ctx: func() context.Context {
 			ctx, cancel := context.WithCancel(context.Background())
@@ -272,10 +985,10 @@ func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
 			assert.ErrorIs(t, err, context.Canceled)
 		},
 	}, {
-		name:     "fail ByCategory context timeout",
+		name:     "fail Weapons context timeout",
 		category: "sam-ir",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByCategory", mock.Anything, "sam-ir").Return([]*types.Weapon{}, context.DeadlineExceeded)
+			mwp.On("Weapons", mock.Anything, types.WeaponsQuery{Categories: []string{"sam-ir"}}).Return([]*types.Weapon{}, context.DeadlineExceeded)
 		},
 		ctx: func() context.Context {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
@@ -305,7 +1018,7 @@ func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
 				ctx = tt.ctx()
 			}
 
-			res, err := service.GetWeaponsByCategory(ctx, tt.category)
+			res, err := service.GetWeapons(ctx, types.WeaponsQuery{Categories: []string{tt.category}})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -324,11 +1037,114 @@ func TestWeaponsService_GetWeaponsByCategory(t *testing.T) {
 	}
 }
 
+func TestWeaponsService_GetWeaponsPage(t *testing.T) {
+	weapons := []*types.Weapon{
+		{Category: "sam-ir", Name: "9M39 Igla"},
+		{Category: "sam-ir", Name: "AIM-9X"},
+		{Category: "sam-ir", Name: "FB-10"},
+	}
+
+	t.Run("more pages", func(t *testing.T) {
+		mockProvider := new(mockWeaponsProvider)
+		mockProvider.On("Weapons", mock.Anything, types.WeaponsQuery{Page: types.Page{Limit: 3, Offset: 2}}).Return(weapons, nil)
+
+		service := &WeaponsService{
+			provider: mockProvider,
+		}
+
+		res, err := service.GetWeapons(context.Background(), types.WeaponsQuery{Page: types.Page{Limit: 2, Offset: 2}})
+		require.NoError(t, err)
+		assert.Len(t, res.Weapons, 2)
+		assert.Equal(t, cursor.Encode(4, 2), res.NextCursor)
+
+		mockProvider.AssertExpectations(t)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockProvider := new(mockWeaponsProvider)
+		mockProvider.On("Weapons", mock.Anything, types.WeaponsQuery{Page: types.Page{Limit: 4}}).Return(weapons, nil)
+
+		service := &WeaponsService{
+			provider: mockProvider,
+		}
+
+		res, err := service.GetWeapons(context.Background(), types.WeaponsQuery{Page: types.Page{Limit: 3}})
+		require.NoError(t, err)
+		assert.Len(t, res.Weapons, 3)
+		assert.Empty(t, res.NextCursor)
+
+		mockProvider.AssertExpectations(t)
+	})
+}
+
+func TestWeaponsService_GetWeaponByID(t *testing.T) {
+	weapon := &types.Weapon{ID: "1", Category: "sam-ir", Name: "FB-10"}
+
+	tests := []struct {
+		name     string
+		mocks    func(*mockWeaponsProvider)
+		wantErr  bool
+		checkErr func(*testing.T, error)
+	}{
+		{
+			name: "success",
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponByID", mock.Anything, "1").Return(weapon, nil)
+			},
+		},
+		{
+			name: "fail weapon not found",
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponByID", mock.Anything, "1").Return((*types.Weapon)(nil), mongodb.ErrNoWeapon)
+			},
+			wantErr: true,
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrNoWeapons)
+			},
+		},
+		{
+			name: "fail WeaponByID error",
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponByID", mock.Anything, "1").Return((*types.Weapon)(nil), errors.New("failed to find document"))
+			},
+			wantErr: true,
+			checkErr: func(t *testing.T, err error) {
+				assert.Contains(t, err.Error(), "failed to find document")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := new(mockWeaponsProvider)
+			tt.mocks(mockProvider)
+
+			service := &WeaponsService{
+				provider: mockProvider,
+			}
+
+			res, err := service.GetWeaponByID(context.Background(), "1")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, res)
+				tt.checkErr(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, weapon, res)
+			}
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
+
 func TestWeaponsService_SearchWeapons(t *testing.T) {
-	results := []types.SearchResult{
+	weapons := []*types.Weapon{
 		{Category: "sam-ir", Name: "AIM-9X"},
 		{Category: "aam-ir-rear-aspect", Name: "AIM-9B"},
 		{Category: "aam-ir-all-aspect", Name: "AIM-9M"},
+		{Category: "sam-ir", Name: "FB-10"},
 	}
 
 	tests := []struct {
@@ -343,14 +1159,14 @@ func TestWeaponsService_SearchWeapons(t *testing.T) {
 		name:  "success",
 		query: "aim",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByName", mock.Anything, "aim").Return(results, nil)
+			mwp.On("SearchableWeapons", mock.Anything).Return(weapons, nil)
 		},
 		wantErr: false,
 	}, {
 		name:  "fail Search error",
 		query: "qn",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByName", mock.Anything, "qn").Return([]types.SearchResult{}, errors.New("failed to find documents"))
+			mwp.On("SearchableWeapons", mock.Anything).Return([]*types.Weapon{}, errors.New("failed to find documents"))
 		},
 		wantErr:     true,
 		containsErr: "failed to find documents",
@@ -361,7 +1177,7 @@ func TestWeaponsService_SearchWeapons(t *testing.T) {
 		name:  "fail Search context cancelled",
 		query: "hn",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByName", mock.Anything, "hn").Return([]types.SearchResult{}, context.Canceled)
+			mwp.On("SearchableWeapons", mock.Anything).Return([]*types.Weapon{}, context.Canceled)
 		},
 		ctx: func() context.Context {
 			ctx, cancel := context.WithCancel(context.Background())
@@ -377,7 +1193,7 @@ func TestWeaponsService_SearchWeapons(t *testing.T) {
 		name:  "fail Search context timeout",
 		query: "aim",
 		mocks: func(mwp *mockWeaponsProvider) {
-			mwp.On("WeaponsByName", mock.Anything, "aim").Return([]types.SearchResult{}, context.DeadlineExceeded)
+			mwp.On("SearchableWeapons", mock.Anything).Return([]*types.Weapon{}, context.DeadlineExceeded)
 		},
 		ctx: func() context.Context {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
@@ -407,7 +1223,7 @@ func TestWeaponsService_SearchWeapons(t *testing.T) {
 				ctx = tt.ctx()
 			}
 
-			res, err := service.SearchWeapons(ctx, tt.query)
+			res, err := service.SearchWeapons(ctx, tt.query, types.Page{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -419,10 +1235,281 @@ func TestWeaponsService_SearchWeapons(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.NotEmpty(t, res)
-				assert.Len(t, res, 3)
+				assert.Len(t, res.Results, 3)
+			}
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeaponsService_GetWeaponHistory(t *testing.T) {
+	snapshots := []types.WeaponSnapshot{
+		{WeaponID: "1", Version: "2.45.0.1", Weapon: &types.Weapon{ID: "1", Category: "aam-arh", Name: "R-77", Mass: "175"}},
+		{WeaponID: "1", Version: "2.46.0.1", Weapon: &types.Weapon{ID: "1", Category: "aam-arh", Name: "R-77", Mass: "175"}},
+		{WeaponID: "1", Version: "2.47.0.1", Weapon: &types.Weapon{ID: "1", Category: "aam-arh", Name: "R-77", Mass: "190"}},
+	}
+
+	tests := []struct {
+		name        string
+		mocks       func(*mockHistoryProvider)
+		wantErr     bool
+		containsErr string
+		check       func(*testing.T, types.WeaponHistory)
+	}{
+		{
+			name: "success",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("WeaponHistory", mock.Anything, "1").Return(snapshots, nil)
+			},
+			check: func(t *testing.T, history types.WeaponHistory) {
+				assert.Equal(t, "R-77", history.Name)
+				assert.Equal(t, "aam-arh", history.Category)
+				require.Len(t, history.History, 2)
+				assert.Equal(t, "2.45.0.1", history.History[0].Version)
+				assert.Equal(t, "2.47.0.1", history.History[1].Version)
+				assert.Equal(t, []types.FieldChange{{Field: "mass", Old: "175", New: "190"}}, history.History[1].Changes)
+			},
+		},
+		{
+			name: "no history",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("WeaponHistory", mock.Anything, "1").Return([]types.WeaponSnapshot{}, nil)
+			},
+			check: func(t *testing.T, history types.WeaponHistory) {
+				assert.Empty(t, history.History)
+			},
+		},
+		{
+			name: "fail WeaponHistory error",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("WeaponHistory", mock.Anything, "1").Return([]types.WeaponSnapshot{}, errors.New("failed to find documents"))
+			},
+			wantErr:     true,
+			containsErr: "failed to find documents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHistoryProvider := new(mockHistoryProvider)
+			tt.mocks(mockHistoryProvider)
+
+			service := &WeaponsService{
+				history: mockHistoryProvider,
+			}
+
+			res, err := service.GetWeaponHistory(context.Background(), "1")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+			} else {
+				require.NoError(t, err)
+				tt.check(t, res)
+			}
+
+			mockHistoryProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeaponsService_DiffVersions(t *testing.T) {
+	from := []types.WeaponSnapshot{
+		{WeaponID: "1", Weapon: &types.Weapon{ID: "1", Category: "aam-arh", Name: "R-77", Mass: "175"}},
+		{WeaponID: "2", Weapon: &types.Weapon{ID: "2", Category: "aam-arh", Name: "AIM-54A"}},
+		{WeaponID: "3", Weapon: &types.Weapon{ID: "3", Category: "aam-arh", Name: "AIM-120A"}},
+	}
+
+	to := []types.WeaponSnapshot{
+		{WeaponID: "1", Weapon: &types.Weapon{ID: "1", Category: "aam-arh", Name: "R-77", Mass: "190"}},
+		{WeaponID: "3", Weapon: &types.Weapon{ID: "3", Category: "aam-arh", Name: "AIM-120A"}},
+		{WeaponID: "4", Weapon: &types.Weapon{ID: "4", Category: "aam-arh", Name: "AIM-120B"}},
+	}
+
+	tests := []struct {
+		name    string
+		mocks   func(*mockHistoryProvider)
+		wantErr bool
+		checkFn func(*testing.T, types.VersionDiff, error)
+	}{
+		{
+			name: "success",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("SnapshotsByVersion", mock.Anything, "2.45", "aam-arh").Return(from, nil)
+				mhp.On("SnapshotsByVersion", mock.Anything, "2.47", "aam-arh").Return(to, nil)
+			},
+			checkFn: func(t *testing.T, diff types.VersionDiff, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []types.WeaponRef{{ID: "4", Category: "aam-arh", Name: "AIM-120B"}}, diff.Added)
+				assert.Equal(t, []types.WeaponRef{{ID: "2", Category: "aam-arh", Name: "AIM-54A"}}, diff.Removed)
+				require.Len(t, diff.Changed, 1)
+				assert.Equal(t, "1", diff.Changed[0].ID)
+				assert.Equal(t, []types.FieldChange{{Field: "mass", Old: "175", New: "190"}}, diff.Changed[0].Changes)
+			},
+		},
+		{
+			name: "fail unknown version",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("SnapshotsByVersion", mock.Anything, "2.45", "aam-arh").Return([]types.WeaponSnapshot{}, nil)
+			},
+			wantErr: true,
+			checkFn: func(t *testing.T, diff types.VersionDiff, err error) {
+				assert.ErrorIs(t, err, ErrNoSnapshots)
+				assert.Contains(t, err.Error(), "2.45")
+			},
+		},
+		{
+			name: "fail SnapshotsByVersion error",
+			mocks: func(mhp *mockHistoryProvider) {
+				mhp.On("SnapshotsByVersion", mock.Anything, "2.45", "aam-arh").Return(from, nil)
+				mhp.On("SnapshotsByVersion", mock.Anything, "2.47", "aam-arh").Return([]types.WeaponSnapshot{}, errors.New("failed to find documents"))
+			},
+			wantErr: true,
+			checkFn: func(t *testing.T, diff types.VersionDiff, err error) {
+				assert.Contains(t, err.Error(), "failed to find documents")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHistoryProvider := new(mockHistoryProvider)
+			tt.mocks(mockHistoryProvider)
+
+			service := &WeaponsService{
+				history: mockHistoryProvider,
+			}
+
+			res, err := service.DiffVersions(context.Background(), "2.45", "2.47", "aam-arh")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, res)
+			}
+			tt.checkFn(t, res, err)
+
+			mockHistoryProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeaponsService_CompareWeapons(t *testing.T) {
+	weapons := []*types.Weapon{
+		{ID: "1", Category: "aam-arh", Name: "R-77"},
+		{ID: "2", Category: "sam-arh", Name: "9M96E"},
+	}
+
+	tests := []struct {
+		name        string
+		ids         []string
+		mocks       func(*mockWeaponsProvider)
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			name: "success",
+			ids:  []string{"2", "1"},
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponsByIDs", mock.Anything, []string{"2", "1"}).Return(weapons, nil)
+			},
+		},
+		{
+			name: "success by alias",
+			ids:  []string{"legacy-2", "1"},
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponsByIDs", mock.Anything, []string{"legacy-2", "1"}).Return([]*types.Weapon{
+					weapons[0],
+					{ID: "2", Category: "sam-arh", Name: "9M96E", Aliases: []string{"legacy-2"}},
+				}, nil)
+			},
+		},
+		{
+			name: "fail missing weapon",
+			ids:  []string{"1", "3"},
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponsByIDs", mock.Anything, []string{"1", "3"}).Return(weapons[:1], nil)
+			},
+			wantErr:     true,
+			containsErr: "weapons not found: 3",
+		},
+		{
+			name: "fail WeaponsByIDs error",
+			ids:  []string{"1", "2"},
+			mocks: func(mwp *mockWeaponsProvider) {
+				mwp.On("WeaponsByIDs", mock.Anything, []string{"1", "2"}).Return([]*types.Weapon{}, errors.New("failed to find documents"))
+			},
+			wantErr:     true,
+			containsErr: "failed to find documents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := new(mockWeaponsProvider)
+			tt.mocks(mockProvider)
+
+			service := &WeaponsService{
+				provider: mockProvider,
+			}
+
+			res, err := service.CompareWeapons(context.Background(), tt.ids)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+				assert.Empty(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.Weapons, 2)
+				assert.Equal(t, "2", res.Weapons[0].ID)
+				assert.Equal(t, "1", res.Weapons[1].ID)
 			}
 
 			mockProvider.AssertExpectations(t)
 		})
 	}
 }
+
+func TestWeaponsService_SearchWeaponsPage(t *testing.T) {
+	weapons := []*types.Weapon{
+		{ID: "1", Category: "sam-ir", Name: "AIM-9X"},
+		{ID: "2", Category: "aam-ir-rear-aspect", Name: "AIM-9B"},
+		{ID: "3", Category: "aam-ir-all-aspect", Name: "AIM-9M"},
+	}
+
+	mockProvider := new(mockWeaponsProvider)
+	mockProvider.On("SearchableWeapons", mock.Anything).Return(weapons, nil)
+
+	service := &WeaponsService{
+		provider: mockProvider,
+	}
+
+	res, err := service.SearchWeapons(context.Background(), "aim", types.Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, "AIM-9B", res.Results[0].Name)
+	assert.Equal(t, cursor.Encode(2, 2), res.NextCursor)
+
+	res, err = service.SearchWeapons(context.Background(), "aim", types.Page{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+	assert.Equal(t, "AIM-9X", res.Results[0].Name)
+	assert.Empty(t, res.NextCursor)
+}
+
+func TestWeaponsService_SuggestWeapons(t *testing.T) {
+	refs := []types.WeaponRef{{ID: "1", Name: "AIM-9M", Category: "aam-ir-all-aspect"}}
+
+	mockSuggester := new(mockSuggester)
+	mockSuggester.On("Suggest", "aim", 10).Return(refs)
+
+	service := &WeaponsService{
+		suggester: mockSuggester,
+	}
+
+	res := service.SuggestWeapons(context.Background(), "aim", 10)
+	assert.Equal(t, types.Suggestions{Suggestions: refs}, res)
+
+	mockSuggester.AssertExpectations(t)
+}
@@ -0,0 +1,244 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erknas/wt-guided-weapons/internal/logger"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	legacyVersionField = "_id"
+	legacyVersionID    = "current_version"
+)
+
+var ErrIndexMismatch = errors.New("index mismatch")
+
+type index struct {
+	name   string
+	keys   bson.D
+	unique bool
+}
+
+type migration struct {
+	version int
+	name    string
+	up      func(ctx context.Context, m *MongoDB) error
+}
+
+type migrationRecord struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+var weaponIndexes = []index{
+	{name: "id_unique", keys: bson.D{{Key: FieldWeaponID, Value: 1}}, unique: true},
+	{name: "category_name", keys: bson.D{{Key: FieldWeaponsCategory, Value: 1}, {Key: FieldWeaponName, Value: 1}}},
+	{name: "aliases", keys: bson.D{{Key: FieldAliases, Value: 1}}},
+	{name: "name_notes_text", keys: bson.D{{Key: FieldWeaponName, Value: "text"}, {Key: FieldAdditionalNotes, Value: "text"}}},
+}
+
+var historyIndexes = []index{
+	{name: "weapon_recorded_at", keys: bson.D{{Key: FieldSnapshotWeapon, Value: 1}, {Key: FieldRecordedAt, Value: 1}}},
+	{name: "version_category", keys: bson.D{{Key: FieldSnapshotVersion, Value: 1}, {Key: FieldSnapshotCategory, Value: 1}}},
+}
+
+var versionIndexes = []index{
+	{name: "ingested_at", keys: bson.D{{Key: FieldIngestedAt, Value: -1}}},
+}
+
+var dataMigrations = []migration{
+	{version: 1, name: "move legacy version document", up: moveLegacyVersion},
+}
+
+func (m *MongoDB) Migrate(ctx context.Context) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	steps := pending(dataMigrations, applied)
+
+	for _, step := range steps {
+		if err := step.up(ctx, m); err != nil {
+			log.Error("Migration error",
+				zap.Int("version", step.version),
+				zap.String("name", step.name),
+				zap.Error(err),
+			)
+			return fmt.Errorf("failed to apply migration %d: %w", step.version, err)
+		}
+
+		record := migrationRecord{
+			Version:   step.version,
+			Name:      step.name,
+			AppliedAt: time.Now().UTC(),
+		}
+
+		if _, err := m.migrations.InsertOne(ctx, record); err != nil {
+			log.Error("InsertOne error",
+				zap.Error(err),
+			)
+			return fmt.Errorf("failed to record migration %d: %w", step.version, err)
+		}
+
+		log.Info("Migration applied",
+			zap.Int("version", step.version),
+			zap.String("name", step.name),
+		)
+	}
+
+	collections := []struct {
+		coll    *mongo.Collection
+		indexes []index
+	}{
+		{coll: m.coll, indexes: weaponIndexes},
+		{coll: m.history, indexes: historyIndexes},
+		{coll: m.versions, indexes: versionIndexes},
+	}
+
+	for _, c := range collections {
+		if err := ensureIndexes(ctx, c.coll, c.indexes); err != nil {
+			log.Error("ensureIndexes error",
+				zap.String("collection", c.coll.Name()),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	log.Debug("Migrate complited",
+		zap.Int("applied migrations", len(steps)),
+	)
+
+	return nil
+}
+
+func (m *MongoDB) appliedMigrations(ctx context.Context) (map[int]struct{}, error) {
+	cursor, err := m.migrations.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode migrations: %w", err)
+	}
+
+	applied := make(map[int]struct{}, len(records))
+	for _, record := range records {
+		applied[record.Version] = struct{}{}
+	}
+
+	return applied, nil
+}
+
+func pending(migrations []migration, applied map[int]struct{}) []migration {
+	var steps []migration
+
+	for _, step := range migrations {
+		if _, ok := applied[step.version]; !ok {
+			steps = append(steps, step)
+		}
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].version < steps[j].version
+	})
+
+	return steps
+}
+
+func ensureIndexes(ctx context.Context, coll *mongo.Collection, indexes []index) error {
+	models := make([]mongo.IndexModel, 0, len(indexes))
+
+	for _, idx := range indexes {
+		opts := options.Index().SetName(idx.name)
+		if idx.unique {
+			opts.SetUnique(true)
+		}
+		models = append(models, mongo.IndexModel{Keys: idx.keys, Options: opts})
+	}
+
+	if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes on %s: %w", coll.Name(), err)
+	}
+
+	specs, err := coll.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list indexes on %s: %w", coll.Name(), err)
+	}
+
+	return verifyIndexes(coll.Name(), specs, indexes)
+}
+
+func verifyIndexes(coll string, specs []mongo.IndexSpecification, indexes []index) error {
+	existing := make(map[string]mongo.IndexSpecification, len(specs))
+	for _, spec := range specs {
+		existing[spec.Name] = spec
+	}
+
+	for _, idx := range indexes {
+		spec, ok := existing[idx.name]
+		if !ok {
+			return fmt.Errorf("%w: %s.%s is missing", ErrIndexMismatch, coll, idx.name)
+		}
+
+		unique := spec.Unique != nil && *spec.Unique
+		if unique != idx.unique {
+			return fmt.Errorf("%w: %s.%s unique is %t", ErrIndexMismatch, coll, idx.name, unique)
+		}
+	}
+
+	return nil
+}
+
+func moveLegacyVersion(ctx context.Context, m *MongoDB) error {
+	filter := bson.M{legacyVersionField: legacyVersionID}
+
+	var legacy struct {
+		Version types.VersionInfo `bson:"version"`
+	}
+
+	err := m.coll.FindOne(ctx, filter).Decode(&legacy)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find legacy version: %w", err)
+	}
+
+	if _, err := m.Version(ctx); err != nil {
+		if !errors.Is(err, ErrNoVersion) {
+			return err
+		}
+
+		ingest := types.LastChange{
+			VersionInfo: legacy.Version,
+			IngestedAt:  time.Now().UTC(),
+			Trigger:     types.TriggerMigration,
+		}
+
+		if err := m.RecordIngest(ctx, ingest); err != nil {
+			return err
+		}
+	}
+
+	if _, err := m.coll.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete legacy version: %w", err)
+	}
+
+	return nil
+}
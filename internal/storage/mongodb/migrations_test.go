@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestDataMigrations_Versions(t *testing.T) {
+	for i, step := range dataMigrations {
+		assert.Equal(t, i+1, step.version)
+		assert.NotEmpty(t, step.name)
+		assert.NotNil(t, step.up)
+	}
+}
+
+func TestPending(t *testing.T) {
+	migrations := []migration{
+		{version: 3, name: "third"},
+		{version: 1, name: "first"},
+		{version: 2, name: "second"},
+	}
+
+	tests := []struct {
+		name    string
+		applied map[int]struct{}
+		want    []int
+	}{
+		{
+			name:    "fresh database",
+			applied: map[int]struct{}{},
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "partially applied",
+			applied: map[int]struct{}{1: {}},
+			want:    []int{2, 3},
+		},
+		{
+			name:    "all applied",
+			applied: map[int]struct{}{1: {}, 2: {}, 3: {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var versions []int
+			for _, step := range pending(migrations, tt.applied) {
+				versions = append(versions, step.version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
+
+func TestVerifyIndexes(t *testing.T) {
+	unique := true
+
+	tests := []struct {
+		name    string
+		specs   []mongo.IndexSpecification
+		wantErr bool
+	}{
+		{
+			name: "all present",
+			specs: []mongo.IndexSpecification{
+				{Name: "_id_"},
+				{Name: "id_unique", Unique: &unique},
+				{Name: "category_name"},
+				{Name: "aliases"},
+				{Name: "name_notes_text"},
+			},
+		},
+		{
+			name: "missing index",
+			specs: []mongo.IndexSpecification{
+				{Name: "id_unique", Unique: &unique},
+				{Name: "category_name"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unique mismatch",
+			specs: []mongo.IndexSpecification{
+				{Name: "id_unique"},
+				{Name: "category_name"},
+				{Name: "aliases"},
+				{Name: "name_notes_text"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyIndexes("weapons", tt.specs, weaponIndexes)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrIndexMismatch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,20 +2,32 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	weaponid "github.com/erknas/wt-guided-weapons/internal/lib/weapon-id"
 	"github.com/erknas/wt-guided-weapons/internal/types"
 )
 
 type MockDB struct {
 	storage map[string]*types.Weapon
+	history map[string][]types.WeaponSnapshot
+	schema  []types.SchemaReport
+	merges  []types.WeaponMerge
+	ingests []types.LastChange
 	mu      sync.RWMutex
 }
 
 func NewMockDB() *MockDB {
 	return &MockDB{
 		storage: make(map[string]*types.Weapon),
+		history: make(map[string][]types.WeaponSnapshot),
 	}
 }
 
@@ -25,7 +37,7 @@ func (m *MockDB) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) err
 
 	for _, weapon := range weapons {
 		if weapon.ID == "" {
-			weapon.ID = generateWeaponID(weapon)
+			weapon.ID = weaponid.Generate(weapon)
 		}
 
 		m.storage[weapon.ID] = weapon
@@ -34,14 +46,77 @@ func (m *MockDB) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) err
 	return nil
 }
 
-func (m *MockDB) WeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error) {
+func (m *MockDB) RetireWeapons(ctx context.Context, category string, keep []string, version string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	retired := 0
+
+	for _, weapon := range m.storage {
+		if weapon.Category != category || weapon.Retired || slices.Contains(keep, weapon.ID) {
+			continue
+		}
+		weapon.Retired = true
+		weapon.RetiredIn = version
+		retired++
+	}
+
+	return retired, nil
+}
+
+func (m *MockDB) Weapons(ctx context.Context, query types.WeaponsQuery) ([]*types.Weapon, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	weapons := make([]*types.Weapon, 0)
 
 	for _, weapon := range m.storage {
-		if weapon.Category == category {
+		if matchesQuery(weapon, query) {
+			weapons = append(weapons, weapon)
+		}
+	}
+
+	sortWeapons(weapons, query.Sort)
+
+	start, end := pageBounds(len(weapons), query.Page)
+	weapons = weapons[start:end]
+
+	if len(query.Fields) > 0 {
+		for i, weapon := range weapons {
+			weapons[i] = projectWeapon(weapon, query.Fields)
+		}
+	}
+
+	return weapons, nil
+}
+
+func (m *MockDB) WeaponByID(ctx context.Context, id string) (*types.Weapon, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if weapon, ok := m.storage[id]; ok {
+		return weapon, nil
+	}
+
+	for _, weapon := range m.storage {
+		if slices.Contains(weapon.Aliases, id) {
+			return weapon, nil
+		}
+	}
+
+	return nil, ErrNoWeapon
+}
+
+func (m *MockDB) WeaponsByIDs(ctx context.Context, ids []string) ([]*types.Weapon, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	weapons := make([]*types.Weapon, 0, len(ids))
+
+	for _, weapon := range m.storage {
+		if slices.Contains(ids, weapon.ID) || slices.ContainsFunc(weapon.Aliases, func(alias string) bool {
+			return slices.Contains(ids, alias)
+		}) {
 			weapons = append(weapons, weapon)
 		}
 	}
@@ -49,18 +124,405 @@ func (m *MockDB) WeaponsByCategory(ctx context.Context, category string) ([]*typ
 	return weapons, nil
 }
 
-func (m *MockDB) WeaponsByName(ctx context.Context, query string) ([]types.SearchResult, error) {
+func (m *MockDB) SearchableWeapons(ctx context.Context) ([]*types.Weapon, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	weapons := make([]*types.Weapon, 0, len(m.storage))
+
+	for _, weapon := range m.storage {
+		if weapon.Retired {
+			continue
+		}
+		weapons = append(weapons, &types.Weapon{
+			ID:              weapon.ID,
+			Category:        weapon.Category,
+			Name:            weapon.Name,
+			GuidanceType:    weapon.GuidanceType,
+			Warhead:         weapon.Warhead,
+			AdditionalNotes: weapon.AdditionalNotes,
+		})
+	}
+
+	return weapons, nil
+}
+
+func (m *MockDB) RecordIngest(ctx context.Context, ingest types.LastChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ingests = append(m.ingests, ingest)
+
+	return nil
+}
+
+func (m *MockDB) CompleteIngest(ctx context.Context, ingest types.LastChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.ingests {
+		if m.ingests[i].ID == ingest.ID && m.ingests[i].Pending {
+			m.ingests[i].Pending = false
+			m.ingests[i].IngestedAt = ingest.IngestedAt
+			m.ingests[i].DurationMs = ingest.DurationMs
+			return nil
+		}
+	}
+
+	return ErrNoVersion
+}
+
+func (m *MockDB) Version(ctx context.Context) (types.LastChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var latest *types.LastChange
+
+	for i := range m.ingests {
+		ingest := &m.ingests[i]
+		if ingest.Pending {
+			continue
+		}
+		if latest == nil || !ingest.IngestedAt.Before(latest.IngestedAt) {
+			latest = ingest
+		}
+	}
+
+	if latest == nil {
+		return types.LastChange{}, ErrNoVersion
+	}
+
+	return *latest, nil
+}
+
+func (m *MockDB) Stage(ctx context.Context) (Dataset, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	staged := NewMockDB()
+
+	for id, weapon := range m.storage {
+		clone := *weapon
+		staged.storage[id] = &clone
+	}
+
+	return staged, nil
+}
+
+func (m *MockDB) Promote(ctx context.Context, dataset Dataset) error {
+	staged, ok := dataset.(*MockDB)
+	if !ok || staged == m {
+		return fmt.Errorf("%w: not a staged collection", ErrInvalidDataset)
+	}
+
+	staged.mu.RLock()
+	defer staged.mu.RUnlock()
+
+	active := 0
+	for _, weapon := range staged.storage {
+		if !weapon.Retired {
+			active++
+		}
+	}
+
+	if active == 0 {
+		return fmt.Errorf("%w: no weapons", ErrInvalidDataset)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.storage = staged.storage
+
+	return nil
+}
+
+func (m *MockDB) Discard(ctx context.Context, dataset Dataset) error {
+	return nil
+}
+
+func (m *MockDB) RecordMerges(ctx context.Context, merges []types.WeaponMerge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, merge := range merges {
+		i := slices.IndexFunc(m.merges, func(recorded types.WeaponMerge) bool {
+			return recorded.WeaponID == merge.WeaponID && recorded.Alias == merge.Alias && recorded.Version == merge.Version
+		})
+		if i >= 0 {
+			m.merges[i] = merge
+			continue
+		}
+		m.merges = append(m.merges, merge)
+	}
+
+	return nil
+}
+
+func (m *MockDB) RecordSnapshots(ctx context.Context, version string, weapons []*types.Weapon) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recordedAt := time.Now().UTC()
+
+	for _, weapon := range weapons {
+		snapshot := types.WeaponSnapshot{
+			WeaponID:   weapon.ID,
+			Version:    version,
+			RecordedAt: recordedAt,
+			Weapon:     weapon,
+		}
+
+		snapshots := m.history[weapon.ID]
+
+		if n := len(snapshots); n > 0 && snapshots[n-1].Version == version {
+			snapshot.RecordedAt = snapshots[n-1].RecordedAt
+			snapshots[n-1] = snapshot
+			continue
+		}
+
+		m.history[weapon.ID] = append(snapshots, snapshot)
+	}
+
+	return nil
+}
+
+func (m *MockDB) WeaponHistory(ctx context.Context, id string) ([]types.WeaponSnapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]types.WeaponSnapshot(nil), m.history[id]...), nil
+}
+
+func (m *MockDB) SnapshotsByVersion(ctx context.Context, version, category string) ([]types.WeaponSnapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshots := make([]types.WeaponSnapshot, 0)
+
+	for _, history := range m.history {
+		for _, snapshot := range history {
+			if snapshot.Version != version {
+				continue
+			}
+			if category != "" && snapshot.Weapon.Category != category {
+				continue
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (m *MockDB) RecordSchemaReport(ctx context.Context, report types.SchemaReport) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.schema = slices.DeleteFunc(m.schema, func(r types.SchemaReport) bool {
+		return r.Version == report.Version
+	})
+	m.schema = append(m.schema, report)
+
+	return nil
+}
+
+func (m *MockDB) SchemaReport(ctx context.Context, version string) (types.SchemaReport, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	results := make([]types.SearchResult, 0)
+	for i := len(m.schema) - 1; i >= 0; i-- {
+		if version == "" || m.schema[i].Version == version {
+			return m.schema[i], nil
+		}
+	}
+
+	return types.SchemaReport{}, ErrNoSchema
+}
+
+func matchesQuery(weapon *types.Weapon, query types.WeaponsQuery) bool {
+	if len(query.Categories) > 0 && !slices.Contains(query.Categories, weapon.Category) {
+		return false
+	}
+
+	if weapon.Retired && !query.IncludeRetired {
+		return false
+	}
+
+	if len(query.Guidance) > 0 && !containsAnyFold(weapon.GuidanceType, query.Guidance) {
+		return false
+	}
+
+	if len(query.Warheads) > 0 && !containsAnyFold(weapon.Warhead, query.Warheads) {
+		return false
+	}
+
+	return matchesFilters(weapon, query.Filters)
+}
+
+func containsAnyFold(value string, terms []string) bool {
+	value = strings.ToLower(value)
+
+	for _, term := range terms {
+		if strings.Contains(value, strings.ToLower(term)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesFilters(weapon *types.Weapon, filters []types.Filter) bool {
+	for _, f := range filters {
+		if !matchesFilter(weapon, f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesFilter(weapon *types.Weapon, f types.Filter) bool {
+	field, ok := weaponfields.Lookup(f.Field)
+	if !ok {
+		return false
+	}
+
+	if want, err := strconv.ParseFloat(f.Value, 64); err == nil && !field.Text {
+		stat, ok := weapon.Stats[f.Field]
+		if !ok || stat.Value == nil {
+			return f.Operator == types.OpNe
+		}
+
+		got := *stat.Value
+
+		switch f.Operator {
+		case types.OpEq:
+			return got == want
+		case types.OpNe:
+			return got != want
+		case types.OpGt:
+			return got > want
+		case types.OpGte:
+			return got >= want
+		case types.OpLt:
+			return got < want
+		case types.OpLte:
+			return got <= want
+		}
+
+		return false
+	}
+
+	equal := strings.EqualFold(field.Value(weapon), f.Value)
+
+	if f.Operator == types.OpNe {
+		return !equal
+	}
+
+	return equal
+}
+
+func sortWeapons(weapons []*types.Weapon, fields []types.SortField) {
+	sort.SliceStable(weapons, func(i, j int) bool {
+		for _, s := range fields {
+			if c := compareWeapons(weapons[i], weapons[j], s.Field); c != 0 {
+				return (c < 0) != s.Desc
+			}
+		}
+		return false
+	})
+}
+
+func compareWeapons(a, b *types.Weapon, key string) int {
+	av, bv := a.Stats[key].Value, b.Stats[key].Value
+
+	switch {
+	case av != nil && bv != nil:
+		switch {
+		case *av < *bv:
+			return -1
+		case *av > *bv:
+			return 1
+		}
+		return 0
+	case av != nil:
+		return 1
+	case bv != nil:
+		return -1
+	}
+
+	field, ok := weaponfields.Lookup(key)
+	if !ok {
+		return 0
+	}
+
+	return strings.Compare(field.Value(a), field.Value(b))
+}
+
+func (m *MockDB) CategoryCounts(ctx context.Context) (map[string]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[string]int)
 
 	for _, weapon := range m.storage {
-		if strings.Contains(strings.ToLower(weapon.Name), strings.ToLower(query)) {
-			result := types.SearchResult{Category: weapon.Category, Name: weapon.Name}
-			results = append(results, result)
+		if weapon.Retired {
+			continue
+		}
+		counts[weapon.Category]++
+	}
+
+	return counts, nil
+}
+
+func (m *MockDB) CategoryVersions(ctx context.Context) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ingests := make([]types.LastChange, 0, len(m.ingests))
+	for _, ingest := range m.ingests {
+		if !ingest.Pending {
+			ingests = append(ingests, ingest)
+		}
+	}
+
+	sort.SliceStable(ingests, func(i, j int) bool {
+		return ingests[i].IngestedAt.Before(ingests[j].IngestedAt)
+	})
+
+	return categoryVersions(ingests), nil
+}
+
+func pageBounds(total int, page types.Page) (int, int) {
+	start := min(page.Offset, total)
+	if page.Limit <= 0 {
+		return start, total
+	}
+	return start, min(start+page.Limit, total)
+}
+
+func projectWeapon(weapon *types.Weapon, fields []string) *types.Weapon {
+	projected := &types.Weapon{
+		ID:       weapon.ID,
+		Category: weapon.Category,
+		Name:     weapon.Name,
+	}
+
+	for _, key := range fields {
+		field, ok := weaponfields.Lookup(key)
+		if !ok {
+			continue
+		}
+		field.Set(projected, field.Value(weapon))
+
+		if stat, ok := weapon.Stats[key]; ok {
+			if projected.Stats == nil {
+				projected.Stats = make(types.Stats)
+			}
+			projected.Stats[key] = stat
 		}
 	}
 
-	return results, nil
+	return projected
 }
@@ -4,31 +4,63 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"time"
 
 	"github.com/erknas/wt-guided-weapons/internal/config"
+	weaponid "github.com/erknas/wt-guided-weapons/internal/lib/weapon-id"
 	"github.com/erknas/wt-guided-weapons/internal/logger"
 	"github.com/erknas/wt-guided-weapons/internal/types"
-	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.uber.org/zap"
 )
 
 const (
-	FieldWeaponID        = "id"
-	FieldWeaponsCategory = "category"
-	FieldWeaponName      = "name"
-	FieldVersionID       = "_id"
-	CurrentVersion       = "current_version"
+	FieldWeaponID         = "id"
+	FieldWeaponsCategory  = "category"
+	FieldWeaponName       = "name"
+	FieldStats            = "stats"
+	FieldQuantities       = "quantities"
+	FieldExtra            = "extra"
+	FieldRetired          = "retired"
+	FieldRetiredIn        = "retired_in"
+	FieldAliases          = "aliases"
+	FieldColumn           = "column"
+	FieldGuidanceType     = "guidance_type"
+	FieldWarhead          = "warhead"
+	FieldAdditionalNotes  = "additional_notes"
+	FieldIngestedAt       = "ingested_at"
+	FieldIngestID         = "_id"
+	FieldIngestPending    = "pending"
+	FieldIngestDuration   = "duration_ms"
+	FieldIngestVersion    = "version"
+	FieldSourceHashes     = "source_hashes"
+	FieldMergeWeapon      = "weapon_id"
+	FieldMergeAlias       = "alias"
+	FieldMergeVersion     = "version"
+	FieldSnapshotWeapon   = "weapon_id"
+	FieldSnapshotVersion  = "version"
+	FieldRecordedAt       = "recorded_at"
+	FieldSnapshotCategory = "weapon.category"
+	FieldSchemaVersion    = "_id"
+	FieldSchemaCreatedAt  = "created_at"
 )
 
-var ErrNoVersion = errors.New("version not found")
+var (
+	ErrNoVersion = errors.New("version not found")
+	ErrNoWeapon  = errors.New("weapon not found")
+	ErrNoSchema  = errors.New("schema report not found")
+)
 
 type MongoDB struct {
-	client *mongo.Client
-	coll   *mongo.Collection
+	client     *mongo.Client
+	coll       *mongo.Collection
+	history    *mongo.Collection
+	schema     *mongo.Collection
+	merges     *mongo.Collection
+	versions   *mongo.Collection
+	migrations *mongo.Collection
 }
 
 func New(ctx context.Context, cfg *config.Config) (*MongoDB, error) {
@@ -46,11 +78,16 @@ func New(ctx context.Context, cfg *config.Config) (*MongoDB, error) {
 		return nil, fmt.Errorf("failed to send ping: %w", err)
 	}
 
-	coll := client.Database(cfg.ConfigMongoDB.DBName).Collection(cfg.ConfigMongoDB.CollName)
+	db := client.Database(cfg.ConfigMongoDB.DBName)
 
 	return &MongoDB{
-		client: client,
-		coll:   coll,
+		client:     client,
+		coll:       db.Collection(cfg.ConfigMongoDB.CollName),
+		history:    db.Collection(cfg.ConfigMongoDB.HistoryColl),
+		schema:     db.Collection(cfg.ConfigMongoDB.SchemaColl),
+		merges:     db.Collection(cfg.ConfigMongoDB.MergesColl),
+		versions:   db.Collection(cfg.ConfigMongoDB.VersionsColl),
+		migrations: db.Collection(cfg.ConfigMongoDB.MigrationsColl),
 	}, nil
 }
 
@@ -61,7 +98,7 @@ func (m *MongoDB) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) er
 
 	for _, weapon := range weapons {
 		if weapon.ID == "" {
-			weapon.ID = generateWeaponID(weapon)
+			weapon.ID = weaponid.Generate(weapon)
 		}
 
 		filter := bson.M{FieldWeaponID: weapon.ID}
@@ -75,6 +112,10 @@ func (m *MongoDB) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) er
 		models = append(models, model)
 	}
 
+	if len(models) == 0 {
+		return nil
+	}
+
 	res, err := m.coll.BulkWrite(ctx, models)
 	if err != nil {
 		log.Error("BulkWrite error",
@@ -92,12 +133,53 @@ func (m *MongoDB) UpsertWeapons(ctx context.Context, weapons []*types.Weapon) er
 	return nil
 }
 
-func (m *MongoDB) WeaponsByCategory(ctx context.Context, category string) ([]*types.Weapon, error) {
+func (m *MongoDB) RetireWeapons(ctx context.Context, category string, keep []string, version string) (int, error) {
 	log := logger.FromContext(ctx, logger.Storage)
 
-	filter := bson.M{FieldWeaponsCategory: category}
+	filter := bson.M{
+		FieldWeaponsCategory: category,
+		FieldWeaponID:        bson.M{"$nin": keep},
+		FieldRetired:         bson.M{"$ne": true},
+	}
+	update := bson.M{"$set": bson.M{
+		FieldRetired:   true,
+		FieldRetiredIn: version,
+	}}
 
-	cursor, err := m.coll.Find(ctx, filter)
+	res, err := m.coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Error("UpdateMany error",
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("failed to retire documents: %w", err)
+	}
+
+	log.Debug("RetireWeapons complited",
+		zap.String("category", category),
+		zap.String("version", version),
+		zap.Int("modified count", int(res.ModifiedCount)),
+	)
+
+	return int(res.ModifiedCount), nil
+}
+
+func (m *MongoDB) Weapons(ctx context.Context, query types.WeaponsQuery) ([]*types.Weapon, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := weaponsFilter(query)
+	opts := options.Find()
+
+	if sort := weaponsSort(query); len(sort) > 0 {
+		opts.SetSort(sort)
+	}
+
+	if projection := weaponsProjection(query); projection != nil {
+		opts.SetProjection(projection)
+	}
+
+	applyPage(opts, query.Page)
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
 	if err != nil {
 		log.Error("Find error",
 			zap.Error(err),
@@ -122,24 +204,31 @@ func (m *MongoDB) WeaponsByCategory(ctx context.Context, category string) ([]*ty
 		return nil, fmt.Errorf("last cursor error: %w", err)
 	}
 
-	log.Debug("WeaponsByCategory complited",
+	log.Debug("Weapons complited",
+		zap.Strings("categories", query.Categories),
 		zap.Int("total documents found", len(weapons)),
 	)
 
 	return weapons, nil
 }
 
-func (m *MongoDB) WeaponsByName(ctx context.Context, query string) ([]types.SearchResult, error) {
+func (m *MongoDB) SearchableWeapons(ctx context.Context) ([]*types.Weapon, error) {
 	log := logger.FromContext(ctx, logger.Storage)
 
 	filter := bson.M{
-		FieldWeaponName: bson.M{
-			"$regex":   regexp.QuoteMeta(query),
-			"$options": "i",
-		},
+		FieldWeaponsCategory: bson.M{"$exists": true},
+		FieldRetired:         bson.M{"$ne": true},
 	}
-
-	cursor, err := m.coll.Find(ctx, filter)
+	opts := options.Find().SetProjection(bson.M{
+		FieldWeaponID:        1,
+		FieldWeaponsCategory: 1,
+		FieldWeaponName:      1,
+		FieldGuidanceType:    1,
+		FieldWarhead:         1,
+		FieldAdditionalNotes: 1,
+	})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
 	if err != nil {
 		log.Error("Find error",
 			zap.Error(err),
@@ -148,9 +237,9 @@ func (m *MongoDB) WeaponsByName(ctx context.Context, query string) ([]types.Sear
 	}
 	defer cursor.Close(ctx)
 
-	var results []types.SearchResult
+	var weapons []*types.Weapon
 
-	if err := cursor.All(ctx, &results); err != nil {
+	if err := cursor.All(ctx, &weapons); err != nil {
 		log.Error("Decode error",
 			zap.Error(err),
 		)
@@ -164,21 +253,87 @@ func (m *MongoDB) WeaponsByName(ctx context.Context, query string) ([]types.Sear
 		return nil, fmt.Errorf("last cursor error: %w", err)
 	}
 
-	log.Debug("WeaponsByName complited",
-		zap.Int("total documents found", len(results)),
+	log.Debug("SearchableWeapons complited",
+		zap.Int("total documents found", len(weapons)),
+	)
+
+	return weapons, nil
+}
+
+func (m *MongoDB) WeaponByID(ctx context.Context, id string) (*types.Weapon, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{FieldWeaponID: id},
+		bson.M{FieldAliases: id},
+	}}
+
+	weapon := new(types.Weapon)
+
+	err := m.coll.FindOne(ctx, filter).Decode(weapon)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Warn("Weapon not found",
+				zap.String("id", id),
+			)
+			return nil, ErrNoWeapon
+		}
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find document: %w", err)
+	}
+
+	log.Debug("WeaponByID complited",
+		zap.String("id", id),
+	)
+
+	return weapon, nil
+}
+
+func (m *MongoDB) WeaponsByIDs(ctx context.Context, ids []string) ([]*types.Weapon, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{FieldWeaponID: bson.M{"$in": ids}},
+		bson.M{FieldAliases: bson.M{"$in": ids}},
+	}}
+
+	cursor, err := m.coll.Find(ctx, filter)
+	if err != nil {
+		log.Error("Find error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var weapons []*types.Weapon
+
+	if err := cursor.All(ctx, &weapons); err != nil {
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	log.Debug("WeaponsByIDs complited",
+		zap.Int("total documents found", len(weapons)),
 	)
 
-	return results, nil
+	return weapons, nil
 }
 
 func (m *MongoDB) Version(ctx context.Context) (types.LastChange, error) {
 	log := logger.FromContext(ctx, logger.Storage)
 
-	filter := bson.M{FieldVersionID: CurrentVersion}
+	opts := options.FindOne().SetSort(bson.D{{Key: FieldIngestedAt, Value: -1}})
 
 	var version types.LastChange
 
-	err := m.coll.FindOne(ctx, filter).Decode(&version)
+	filter := bson.M{FieldIngestPending: bson.M{"$ne": true}}
+
+	err := m.versions.FindOne(ctx, filter, opts).Decode(&version)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			log.Warn("Version not found",
@@ -199,24 +354,324 @@ func (m *MongoDB) Version(ctx context.Context) (types.LastChange, error) {
 	return version, nil
 }
 
-func (m *MongoDB) UpsertVersion(ctx context.Context, version types.VersionInfo) error {
+func (m *MongoDB) RecordIngest(ctx context.Context, ingest types.LastChange) error {
 	log := logger.FromContext(ctx, logger.Storage)
 
-	opts := options.UpdateOne().SetUpsert(true)
+	if _, err := m.versions.InsertOne(ctx, ingest); err != nil {
+		log.Error("InsertOne error",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to insert document: %w", err)
+	}
+
+	log.Debug("RecordIngest complited",
+		zap.String("version", ingest.Version),
+		zap.String("trigger", ingest.Trigger),
+	)
+
+	return nil
+}
 
-	filter := bson.M{FieldVersionID: CurrentVersion}
-	update := updateVersion(version)
+func (m *MongoDB) CompleteIngest(ctx context.Context, ingest types.LastChange) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{FieldIngestID: ingest.ID, FieldIngestPending: true}
+	update := bson.M{
+		"$set": bson.M{
+			FieldIngestedAt:     ingest.IngestedAt,
+			FieldIngestDuration: ingest.DurationMs,
+		},
+		"$unset": bson.M{FieldIngestPending: ""},
+	}
 
-	res, err := m.coll.UpdateOne(ctx, filter, update, opts)
+	res, err := m.versions.UpdateOne(ctx, filter, update)
 	if err != nil {
-		log.Error("Update error",
+		log.Error("UpdateOne error",
 			zap.Error(err),
 		)
 		return fmt.Errorf("failed to update document: %w", err)
 	}
 
-	log.Debug("UpsertVersion complited",
-		zap.Int("matched count", int(res.MatchedCount)),
+	if res.MatchedCount == 0 {
+		log.Warn("Pending ingest not found",
+			zap.String("id", ingest.ID),
+		)
+		return fmt.Errorf("%w: pending ingest %s", ErrNoVersion, ingest.ID)
+	}
+
+	log.Debug("CompleteIngest complited",
+		zap.String("id", ingest.ID),
+		zap.Int64("duration ms", ingest.DurationMs),
+	)
+
+	return nil
+}
+
+func (m *MongoDB) RecordSnapshots(ctx context.Context, version string, weapons []*types.Weapon) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	models := make([]mongo.WriteModel, 0, len(weapons))
+	recordedAt := time.Now().UTC()
+
+	for _, weapon := range weapons {
+		filter := bson.M{
+			FieldSnapshotWeapon:  weapon.ID,
+			FieldSnapshotVersion: version,
+		}
+		update := updateSnapshot(weapon, recordedAt)
+
+		model := mongo.NewUpdateOneModel()
+		model.SetFilter(filter)
+		model.SetUpdate(update)
+		model.SetUpsert(true)
+
+		models = append(models, model)
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	res, err := m.history.BulkWrite(ctx, models)
+	if err != nil {
+		log.Error("BulkWrite error",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to record snapshots: %w", err)
+	}
+
+	log.Debug("RecordSnapshots complited",
+		zap.String("version", version),
+		zap.Int("upserted count", int(res.UpsertedCount)),
+		zap.Int("modified count", int(res.ModifiedCount)),
+	)
+
+	return nil
+}
+
+func (m *MongoDB) RecordMerges(ctx context.Context, merges []types.WeaponMerge) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	if len(merges) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(merges))
+
+	for _, merge := range merges {
+		filter := bson.M{
+			FieldMergeWeapon:  merge.WeaponID,
+			FieldMergeAlias:   merge.Alias,
+			FieldMergeVersion: merge.Version,
+		}
+
+		model := mongo.NewReplaceOneModel()
+		model.SetFilter(filter)
+		model.SetReplacement(merge)
+		model.SetUpsert(true)
+
+		models = append(models, model)
+	}
+
+	res, err := m.merges.BulkWrite(ctx, models)
+	if err != nil {
+		log.Error("BulkWrite error",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to record merges: %w", err)
+	}
+
+	log.Debug("RecordMerges complited",
+		zap.Int("upserted count", int(res.UpsertedCount)),
+		zap.Int("modified count", int(res.ModifiedCount)),
+	)
+
+	return nil
+}
+
+func (m *MongoDB) WeaponHistory(ctx context.Context, id string) ([]types.WeaponSnapshot, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{FieldSnapshotWeapon: id}
+	opts := options.Find().SetSort(bson.D{{Key: FieldRecordedAt, Value: 1}})
+
+	cursor, err := m.history.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error("Find error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []types.WeaponSnapshot
+
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	log.Debug("WeaponHistory complited",
+		zap.String("id", id),
+		zap.Int("total documents found", len(snapshots)),
+	)
+
+	return snapshots, nil
+}
+
+func (m *MongoDB) SnapshotsByVersion(ctx context.Context, version, category string) ([]types.WeaponSnapshot, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{FieldSnapshotVersion: version}
+	if category != "" {
+		filter[FieldSnapshotCategory] = category
+	}
+
+	cursor, err := m.history.Find(ctx, filter)
+	if err != nil {
+		log.Error("Find error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []types.WeaponSnapshot
+
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	log.Debug("SnapshotsByVersion complited",
+		zap.String("version", version),
+		zap.String("category", category),
+		zap.Int("total documents found", len(snapshots)),
+	)
+
+	return snapshots, nil
+}
+
+func (m *MongoDB) CategoryCounts(ctx context.Context) (map[string]int, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			FieldWeaponsCategory: bson.M{"$exists": true},
+			FieldRetired:         bson.M{"$ne": true},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + FieldWeaponsCategory, "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error("Aggregate error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to aggregate documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Category string `bson:"_id"`
+		Count    int    `bson:"count"`
+	}
+
+	if err := cursor.All(ctx, &groups); err != nil {
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.Category] = group.Count
+	}
+
+	log.Debug("CategoryCounts complited",
+		zap.Int("total categories", len(counts)),
+	)
+
+	return counts, nil
+}
+
+func (m *MongoDB) CategoryVersions(ctx context.Context) (map[string]string, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{
+		FieldIngestPending: bson.M{"$ne": true},
+		FieldSourceHashes:  bson.M{"$exists": true},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: FieldIngestedAt, Value: 1}}).
+		SetProjection(bson.M{FieldIngestVersion: 1, FieldSourceHashes: 1})
+
+	cursor, err := m.versions.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error("Find error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var ingests []types.LastChange
+
+	if err := cursor.All(ctx, &ingests); err != nil {
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	versions := categoryVersions(ingests)
+
+	log.Debug("CategoryVersions complited",
+		zap.Int("total categories", len(versions)),
+	)
+
+	return versions, nil
+}
+
+func categoryVersions(ingests []types.LastChange) map[string]string {
+	versions := make(map[string]string)
+	hashes := make(map[string]string)
+
+	for _, ingest := range ingests {
+		for category, hash := range ingest.SourceHashes {
+			if hashes[category] == hash {
+				continue
+			}
+			hashes[category] = hash
+			versions[category] = ingest.Version
+		}
+	}
+
+	return versions
+}
+
+func (m *MongoDB) RecordSchemaReport(ctx context.Context, report types.SchemaReport) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	opts := options.Replace().SetUpsert(true)
+
+	filter := bson.M{FieldSchemaVersion: report.Version}
+
+	res, err := m.schema.ReplaceOne(ctx, filter, report, opts)
+	if err != nil {
+		log.Error("ReplaceOne error",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to replace document: %w", err)
+	}
+
+	log.Debug("RecordSchemaReport complited",
+		zap.String("version", report.Version),
 		zap.Int("upserted count", int(res.UpsertedCount)),
 		zap.Int("modified count", int(res.ModifiedCount)),
 	)
@@ -224,6 +679,39 @@ func (m *MongoDB) UpsertVersion(ctx context.Context, version types.VersionInfo)
 	return nil
 }
 
+func (m *MongoDB) SchemaReport(ctx context.Context, version string) (types.SchemaReport, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	filter := bson.M{}
+	opts := options.FindOne().SetSort(bson.D{{Key: FieldSchemaCreatedAt, Value: -1}})
+
+	if version != "" {
+		filter[FieldSchemaVersion] = version
+	}
+
+	var report types.SchemaReport
+
+	err := m.schema.FindOne(ctx, filter, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Warn("Schema report not found",
+				zap.String("version", version),
+			)
+			return types.SchemaReport{}, ErrNoSchema
+		}
+		log.Error("Decode error",
+			zap.Error(err),
+		)
+		return types.SchemaReport{}, fmt.Errorf("failed to find document: %w", err)
+	}
+
+	log.Debug("SchemaReport complited",
+		zap.String("version", report.Version),
+	)
+
+	return report, nil
+}
+
 func (m *MongoDB) Close(ctx context.Context) error {
 	return m.client.Disconnect(ctx)
 }
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
@@ -49,7 +50,43 @@ func TestMongoDB_UpsertWeapons(t *testing.T) {
 	})
 }
 
-func TestMongoDB_WeaponsByCategory(t *testing.T) {
+func TestMongoDB_RetireWeapons(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "1", Name: "AIM-54A", Category: "aam-arh"},
+		{ID: "2", Name: "AIM-54C", Category: "aam-arh"},
+		{ID: "3", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+	})
+
+	retired, _ := db.RetireWeapons(ctx, "aam-arh", []string{"2"}, "2.47.0.114")
+	assert.Equal(t, 1, retired)
+
+	retired, _ = db.RetireWeapons(ctx, "aam-arh", []string{"2"}, "2.47.0.115")
+	assert.Equal(t, 0, retired)
+
+	weapon, _ := db.WeaponByID(ctx, "1")
+	assert.True(t, weapon.Retired)
+	assert.Equal(t, "2.47.0.114", weapon.RetiredIn)
+
+	weapons, _ := db.Weapons(ctx, types.WeaponsQuery{})
+	assert.Len(t, weapons, 2)
+
+	weapons, _ = db.Weapons(ctx, types.WeaponsQuery{IncludeRetired: true})
+	assert.Len(t, weapons, 3)
+
+	searchable, _ := db.SearchableWeapons(ctx)
+	assert.Len(t, searchable, 2)
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{{ID: "1", Name: "AIM-54A", Category: "aam-arh"}})
+
+	weapons, _ = db.Weapons(ctx, types.WeaponsQuery{})
+	assert.Len(t, weapons, 3)
+}
+
+func TestMongoDB_Weapons(t *testing.T) {
 	ctx := context.Background()
 
 	db := NewMockDB()
@@ -67,47 +104,354 @@ func TestMongoDB_WeaponsByCategory(t *testing.T) {
 
 		category := "aam-ir-all-aspect"
 
-		results, _ := db.WeaponsByCategory(ctx, category)
+		results, _ := db.Weapons(ctx, types.WeaponsQuery{Categories: []string{category}})
 		assert.Equal(t, 3, len(results))
 	})
-}
 
-func TestMongoDB_WeaponsByName(t *testing.T) {
-	ctx := context.Background()
+	t.Run("filter and sort weapons", func(t *testing.T) {
+		db := NewMockDB()
+
+		g := func(v float64) types.Stats {
+			return types.Stats{"maximum_g_load": {Value: &v}}
+		}
 
-	t.Run("find weapons by name", func(t *testing.T) {
+		weapons := []*types.Weapon{
+			{Name: "AIM-9L", Category: "aam-ir-all-aspect", IRCCM: "No", Stats: g(35)},
+			{Name: "AIM-9M", Category: "aam-ir-all-aspect", IRCCM: "Yes", Stats: g(35)},
+			{Name: "R-73", Category: "aam-ir-all-aspect", IRCCM: "Yes", Stats: g(40)},
+			{Name: "R-60", Category: "aam-ir-all-aspect", IRCCM: "Yes", Stats: g(30)},
+		}
+
+		_ = db.UpsertWeapons(ctx, weapons)
+
+		query := types.WeaponsQuery{
+			Filters: []types.Filter{
+				{Field: "maximum_g_load", Operator: types.OpGt, Value: "30"},
+				{Field: "irccm", Operator: types.OpEq, Value: "yes"},
+			},
+			Sort: []types.SortField{{Field: "maximum_g_load", Desc: true}},
+		}
+
+		query.Categories = []string{"aam-ir-all-aspect"}
+
+		results, _ := db.Weapons(ctx, query)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "R-73", results[0].Name)
+		assert.Equal(t, "AIM-9M", results[1].Name)
+	})
+
+	t.Run("find weapons across categories", func(t *testing.T) {
 		db := NewMockDB()
+
 		weapons := []*types.Weapon{
-			{Name: "AIM-9L", Category: "aam-ir-all-aspect"},
-			{Name: "AIM-9M", Category: "aam-ir-all-aspect"},
-			{Name: "AAM-3", Category: "aam-ir-all-aspect"},
-			{Name: "AIM-54", Category: "aam-arh"},
+			{Name: "AIM-120A", Category: "aam-arh", GuidanceType: "ARH", Warhead: "HE"},
+			{Name: "AIM-7F", Category: "aam-sarh", GuidanceType: "SARH", Warhead: "HE"},
+			{Name: "9M96E", Category: "sam-arh", GuidanceType: "ARH", Warhead: "HE"},
+			{Name: "AIM-9L", Category: "aam-ir-all-aspect", GuidanceType: "IR", Warhead: "HE"},
 		}
 
 		_ = db.UpsertWeapons(ctx, weapons)
-		assert.Len(t, db.storage, 4)
 
-		query := "aim"
+		query := types.WeaponsQuery{
+			Categories: []string{"aam-arh", "aam-sarh", "sam-arh", "aam-ir-all-aspect"},
+			Guidance:   []string{"arh"},
+			Sort:       []types.SortField{{Field: "name"}},
+		}
 
-		results, _ := db.WeaponsByName(ctx, query)
+		results, _ := db.Weapons(ctx, query)
 		assert.Len(t, results, 3)
+		assert.Equal(t, "9M96E", results[0].Name)
+		assert.Equal(t, "AIM-120A", results[1].Name)
+		assert.Equal(t, "AIM-7F", results[2].Name)
 	})
 
-	t.Run("find weapons by name empty results", func(t *testing.T) {
+	t.Run("paginate and project weapons", func(t *testing.T) {
 		db := NewMockDB()
+
 		weapons := []*types.Weapon{
-			{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
-			{ID: "2", Name: "AIM-9M", Category: "aam-ir-all-aspect"},
-			{ID: "3", Name: "AAM-3", Category: "aam-ir-all-aspect"},
-			{ID: "4", Name: "AIM-54", Category: "aam-arh"},
+			{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect", Mass: "85", IRCCM: "No"},
+			{ID: "2", Name: "AIM-9M", Category: "aam-ir-all-aspect", Mass: "86", IRCCM: "Yes"},
+			{ID: "3", Name: "R-73", Category: "aam-ir-all-aspect", Mass: "105", IRCCM: "Yes"},
 		}
 
 		_ = db.UpsertWeapons(ctx, weapons)
-		assert.Len(t, db.storage, 4)
 
-		query := "abfa1230"
+		query := types.WeaponsQuery{
+			Sort:   []types.SortField{{Field: "name"}},
+			Fields: []string{"mass"},
+			Page:   types.Page{Limit: 2, Offset: 1},
+		}
+
+		query.Categories = []string{"aam-ir-all-aspect"}
+
+		results, _ := db.Weapons(ctx, query)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "AIM-9M", results[0].Name)
+		assert.Equal(t, "86", results[0].Mass)
+		assert.Empty(t, results[0].IRCCM)
+		assert.Equal(t, "R-73", results[1].Name)
+	})
+}
+
+func TestMongoDB_WeaponByID(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+	})
+
+	t.Run("found", func(t *testing.T) {
+		weapon, err := db.WeaponByID(ctx, "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "AIM-9L", weapon.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		weapon, err := db.WeaponByID(ctx, "2")
+		assert.ErrorIs(t, err, ErrNoWeapon)
+		assert.Nil(t, weapon)
+	})
+}
+
+func TestMongoDB_WeaponsByIDs(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+		{ID: "2", Name: "AIM-54", Category: "aam-arh"},
+		{ID: "3", Name: "9M96E", Category: "sam-arh", Aliases: []string{"5"}},
+	})
+
+	results, _ := db.WeaponsByIDs(ctx, []string{"2", "3", "4"})
+	assert.Len(t, results, 2)
+
+	results, _ = db.WeaponsByIDs(ctx, []string{"5"})
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "3", results[0].ID)
+	}
+}
+
+func TestMongoDB_SearchableWeapons(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect", GuidanceType: "IR", Mass: "85"},
+		{ID: "2", Name: "AIM-54", Category: "aam-arh", Warhead: "HE", AdditionalNotes: "Lofting"},
+	})
+
+	weapons, _ := db.SearchableWeapons(ctx)
+	assert.Len(t, weapons, 2)
+
+	for _, weapon := range weapons {
+		assert.NotEmpty(t, weapon.ID)
+		assert.NotEmpty(t, weapon.Name)
+		assert.Empty(t, weapon.Mass)
+	}
+}
+
+func TestMongoDB_WeaponHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("record snapshots per version", func(t *testing.T) {
+		db := NewMockDB()
+
+		_ = db.RecordSnapshots(ctx, "2.45.0.1", []*types.Weapon{{ID: "1", Name: "AIM-9L", Mass: "85"}})
+		_ = db.RecordSnapshots(ctx, "2.47.0.1", []*types.Weapon{{ID: "1", Name: "AIM-9L", Mass: "86"}})
+		_ = db.RecordSnapshots(ctx, "2.47.0.1", []*types.Weapon{{ID: "1", Name: "AIM-9L", Mass: "87"}})
+
+		snapshots, _ := db.WeaponHistory(ctx, "1")
+		assert.Len(t, snapshots, 2)
+		assert.Equal(t, "2.45.0.1", snapshots[0].Version)
+		assert.Equal(t, "2.47.0.1", snapshots[1].Version)
+		assert.Equal(t, "87", snapshots[1].Weapon.Mass)
+	})
+
+	t.Run("unknown weapon", func(t *testing.T) {
+		db := NewMockDB()
+
+		snapshots, _ := db.WeaponHistory(ctx, "unknown")
+		assert.Empty(t, snapshots)
+	})
+}
+
+func TestMongoDB_SnapshotsByVersion(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_ = db.RecordSnapshots(ctx, "2.45.0.1", []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+		{ID: "2", Name: "AIM-54", Category: "aam-arh"},
+	})
+	_ = db.RecordSnapshots(ctx, "2.47.0.1", []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+	})
+
+	t.Run("all categories", func(t *testing.T) {
+		snapshots, _ := db.SnapshotsByVersion(ctx, "2.45.0.1", "")
+		assert.Len(t, snapshots, 2)
+	})
+
+	t.Run("single category", func(t *testing.T) {
+		snapshots, _ := db.SnapshotsByVersion(ctx, "2.45.0.1", "aam-arh")
+		assert.Len(t, snapshots, 1)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		snapshots, _ := db.SnapshotsByVersion(ctx, "1.0", "")
+		assert.Empty(t, snapshots)
+	})
+}
 
-		results, _ := db.WeaponsByName(ctx, query)
-		assert.Empty(t, results)
+func TestMongoDB_CategoryStats(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	weapons := []*types.Weapon{
+		{ID: "1", Name: "AIM-9L", Category: "aam-ir-all-aspect"},
+		{ID: "2", Name: "AIM-9M", Category: "aam-ir-all-aspect"},
+		{ID: "3", Name: "AIM-54", Category: "aam-arh"},
+	}
+
+	_ = db.UpsertWeapons(ctx, append(weapons, &types.Weapon{ID: "4", Name: "AIM-120A", Category: "aam-arh", Retired: true}))
+
+	counts, _ := db.CategoryCounts(ctx)
+	assert.Equal(t, map[string]int{"aam-ir-all-aspect": 2, "aam-arh": 1}, counts)
+
+	now := time.Now().UTC()
+
+	_ = db.RecordIngest(ctx, types.LastChange{
+		VersionInfo:  types.VersionInfo{Version: "2.45.0.1"},
+		IngestedAt:   now.Add(-2 * time.Hour),
+		SourceHashes: map[string]string{"aam-ir-all-aspect": "abc", "aam-arh": "def"},
+	})
+	_ = db.RecordIngest(ctx, types.LastChange{
+		VersionInfo:  types.VersionInfo{Version: "2.47.0.1"},
+		IngestedAt:   now.Add(-time.Hour),
+		SourceHashes: map[string]string{"aam-ir-all-aspect": "ghi", "aam-arh": "def"},
+	})
+	_ = db.RecordIngest(ctx, types.LastChange{
+		VersionInfo:  types.VersionInfo{Version: "2.49.0.1"},
+		IngestedAt:   now,
+		SourceHashes: map[string]string{"aam-ir-all-aspect": "ghi", "aam-arh": "def"},
 	})
+	_ = db.RecordIngest(ctx, types.LastChange{
+		VersionInfo:  types.VersionInfo{Version: "2.51.0.1"},
+		SourceHashes: map[string]string{"aam-ir-all-aspect": "jkl", "aam-arh": "mno"},
+		Pending:      true,
+	})
+
+	versions, _ := db.CategoryVersions(ctx)
+	assert.Equal(t, map[string]string{"aam-ir-all-aspect": "2.47.0.1", "aam-arh": "2.45.0.1"}, versions)
+}
+
+func TestMongoDB_RecordMerges(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	merge := types.WeaponMerge{WeaponID: "1", Alias: "2", Version: "2.47.0.1", FromName: "AIM-9L", ToName: "AIM-9L/I"}
+
+	_ = db.RecordMerges(ctx, []types.WeaponMerge{merge})
+	_ = db.RecordMerges(ctx, []types.WeaponMerge{merge, {WeaponID: "1", Alias: "3", Version: "2.47.0.1"}})
+
+	assert.Len(t, db.merges, 2)
+}
+
+func TestMongoDB_SchemaReport(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_, err := db.SchemaReport(ctx, "")
+	assert.ErrorIs(t, err, ErrNoSchema)
+
+	first := types.SchemaReport{Version: "2.45.0.1", Categories: []types.CategorySchema{{Category: "aam-arh", Rows: 10}}}
+	second := types.SchemaReport{Version: "2.47.0.1", Categories: []types.CategorySchema{{Category: "aam-arh", Rows: 11}}}
+
+	_ = db.RecordSchemaReport(ctx, first)
+	_ = db.RecordSchemaReport(ctx, second)
+
+	latest, _ := db.SchemaReport(ctx, "")
+	assert.Equal(t, second, latest)
+
+	byVersion, _ := db.SchemaReport(ctx, "2.45.0.1")
+	assert.Equal(t, first, byVersion)
+
+	_, err = db.SchemaReport(ctx, "1.0")
+	assert.ErrorIs(t, err, ErrNoSchema)
+}
+
+func TestMongoDB_Stage(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+	_ = db.UpsertWeapons(ctx, []*types.Weapon{{ID: "1", Name: "AIM-54A", Category: "aam-arh"}})
+
+	t.Run("promote", func(t *testing.T) {
+		dataset, _ := db.Stage(ctx)
+		_ = dataset.UpsertWeapons(ctx, []*types.Weapon{{ID: "2", Name: "AIM-54C", Category: "aam-arh"}})
+
+		weapons, _ := db.Weapons(ctx, types.WeaponsQuery{})
+		assert.Len(t, weapons, 1)
+
+		err := db.Promote(ctx, dataset)
+		assert.NoError(t, err)
+
+		weapons, _ = db.Weapons(ctx, types.WeaponsQuery{})
+		assert.Len(t, weapons, 2)
+	})
+
+	t.Run("reject dataset without active weapons", func(t *testing.T) {
+		dataset, _ := db.Stage(ctx)
+		_, _ = dataset.RetireWeapons(ctx, "aam-arh", []string{}, "2.48.0.1")
+
+		err := db.Promote(ctx, dataset)
+		assert.ErrorIs(t, err, ErrInvalidDataset)
+
+		weapons, _ := db.Weapons(ctx, types.WeaponsQuery{})
+		assert.Len(t, weapons, 2)
+	})
+}
+
+func TestMongoDB_RecordIngest(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewMockDB()
+
+	_, err := db.Version(ctx)
+	assert.ErrorIs(t, err, ErrNoVersion)
+
+	now := time.Now().UTC()
+
+	_ = db.RecordIngest(ctx, types.LastChange{VersionInfo: types.VersionInfo{Version: "2.47.0.1"}, IngestedAt: now, Trigger: types.TriggerVersion})
+	_ = db.RecordIngest(ctx, types.LastChange{VersionInfo: types.VersionInfo{Version: "2.45.0.1"}, IngestedAt: now.Add(-time.Hour), Trigger: types.TriggerStartup})
+
+	version, _ := db.Version(ctx)
+	assert.Equal(t, "2.47.0.1", version.Version)
+	assert.Equal(t, types.TriggerVersion, version.Trigger)
+
+	_ = db.RecordIngest(ctx, types.LastChange{ID: "pending", VersionInfo: types.VersionInfo{Version: "2.49.0.1"}, Pending: true})
+
+	version, _ = db.Version(ctx)
+	assert.Equal(t, "2.47.0.1", version.Version)
+
+	completed := types.LastChange{ID: "pending", IngestedAt: now.Add(time.Hour), DurationMs: 1500}
+
+	assert.ErrorIs(t, db.CompleteIngest(ctx, types.LastChange{ID: "unknown"}), ErrNoVersion)
+	assert.NoError(t, db.CompleteIngest(ctx, completed))
+	assert.ErrorIs(t, db.CompleteIngest(ctx, completed), ErrNoVersion)
+
+	version, _ = db.Version(ctx)
+	assert.Equal(t, "2.49.0.1", version.Version)
+	assert.Equal(t, completed.IngestedAt, version.IngestedAt)
+	assert.Equal(t, int64(1500), version.DurationMs)
+	assert.False(t, version.Pending)
 }
@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erknas/wt-guided-weapons/internal/logger"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.uber.org/zap"
+)
+
+const stagingSuffix = "_staging"
+
+var ErrInvalidDataset = errors.New("invalid dataset")
+
+type Dataset interface {
+	UpsertWeapons(ctx context.Context, weapons []*types.Weapon) error
+	RetireWeapons(ctx context.Context, category string, keep []string, version string) (int, error)
+}
+
+func (m *MongoDB) Stage(ctx context.Context) (Dataset, error) {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	staging := m.coll.Database().Collection(m.coll.Name() + stagingSuffix)
+
+	if err := staging.Drop(ctx); err != nil {
+		log.Error("Drop error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to drop staging collection: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{FieldWeaponsCategory: bson.M{"$exists": true}}}},
+		{{Key: "$out", Value: staging.Name()}},
+	}
+
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error("Aggregate error",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to copy collection: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err := ensureIndexes(ctx, staging, weaponIndexes); err != nil {
+		log.Error("ensureIndexes error",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	staged := *m
+	staged.coll = staging
+
+	log.Debug("Stage complited",
+		zap.String("collection", staging.Name()),
+	)
+
+	return &staged, nil
+}
+
+func (m *MongoDB) Promote(ctx context.Context, dataset Dataset) error {
+	log := logger.FromContext(ctx, logger.Storage)
+
+	staged, ok := dataset.(*MongoDB)
+	if !ok || staged.coll.Name() == m.coll.Name() {
+		return fmt.Errorf("%w: not a staged collection", ErrInvalidDataset)
+	}
+
+	if err := staged.validate(ctx); err != nil {
+		return err
+	}
+
+	db := m.coll.Database()
+
+	cmd := bson.D{
+		{Key: "renameCollection", Value: db.Name() + "." + staged.coll.Name()},
+		{Key: "to", Value: db.Name() + "." + m.coll.Name()},
+		{Key: "dropTarget", Value: true},
+	}
+
+	if err := m.client.Database("admin").RunCommand(ctx, cmd).Err(); err != nil {
+		log.Error("RunCommand error",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to swap collections: %w", err)
+	}
+
+	log.Debug("Promote complited",
+		zap.String("collection", staged.coll.Name()),
+	)
+
+	return nil
+}
+
+func (m *MongoDB) Discard(ctx context.Context, dataset Dataset) error {
+	staged, ok := dataset.(*MongoDB)
+	if !ok || staged.coll.Name() == m.coll.Name() {
+		return fmt.Errorf("%w: not a staged collection", ErrInvalidDataset)
+	}
+
+	if err := staged.coll.Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop staging collection: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoDB) validate(ctx context.Context) error {
+	filter := bson.M{FieldRetired: bson.M{"$ne": true}}
+
+	count, err := m.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: no weapons", ErrInvalidDataset)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func updateSnapshot(weapon *types.Weapon, recordedAt time.Time) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"weapon": weapon,
+		},
+		"$setOnInsert": bson.M{
+			"recorded_at": recordedAt,
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package mongodb
+
+import (
+	"testing"
+
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestUpdateWeapon(t *testing.T) {
+	weapon := &types.Weapon{
+		ID:         "1",
+		Category:   "aam-arh",
+		RetiredIn:  "2.45.0.1",
+		Stats:      types.Stats{"mass": {Unit: "kg"}},
+		Quantities: types.Stats{"mass": {Unit: "kg"}},
+		Extra:      map[string]string{"Datalink range: [km]": "40"},
+		Retired:    true,
+		Aliases:    []string{"a1b2c3"},
+		Column:     3,
+	}
+	for _, field := range weaponfields.Fields() {
+		field.Set(weapon, field.Key)
+	}
+
+	data, err := bson.Marshal(weapon)
+	require.NoError(t, err)
+
+	var doc bson.M
+	require.NoError(t, bson.Unmarshal(data, &doc))
+	delete(doc, FieldWeaponID)
+
+	delete(doc, FieldRetiredIn)
+
+	update := updateWeapon(weapon)
+
+	set, ok := update["$set"].(bson.M)
+	require.True(t, ok)
+
+	assert.Len(t, set, len(doc))
+	for key := range doc {
+		assert.Contains(t, set, key)
+	}
+	assert.Equal(t, "name", set[FieldWeaponName])
+	assert.Equal(t, weapon.Stats, set[FieldStats])
+	assert.Equal(t, weapon.Quantities, set[FieldQuantities])
+	assert.Equal(t, weapon.Extra, set[FieldExtra])
+	assert.Equal(t, bson.M{FieldRetiredIn: ""}, update["$unset"])
+}
@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/erknas/wt-guided-weapons/internal/lib/units"
+	weaponfields "github.com/erknas/wt-guided-weapons/internal/lib/weapon-fields"
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var numericOperators = map[string]string{
+	types.OpEq:  "$eq",
+	types.OpNe:  "$ne",
+	types.OpGt:  "$gt",
+	types.OpGte: "$gte",
+	types.OpLt:  "$lt",
+	types.OpLte: "$lte",
+}
+
+func statValueField(field string) string {
+	return FieldStats + "." + field + ".value"
+}
+
+func fieldPath(key string) string {
+	if field, ok := weaponfields.Lookup(key); ok {
+		return field.BSON
+	}
+	return key
+}
+
+func weaponsFilter(query types.WeaponsQuery) bson.M {
+	filter := bson.M{FieldWeaponsCategory: categoryCondition(query.Categories)}
+
+	if !query.IncludeRetired {
+		filter[FieldRetired] = bson.M{"$ne": true}
+	}
+
+	conditions := make(bson.A, 0, len(query.Filters)+2)
+
+	if len(query.Guidance) > 0 {
+		conditions = append(conditions, containsAny(FieldGuidanceType, query.Guidance))
+	}
+
+	if len(query.Warheads) > 0 {
+		conditions = append(conditions, equalsAny(FieldWarhead, query.Warheads))
+	}
+
+	for _, f := range query.Filters {
+		conditions = append(conditions, fieldCondition(f))
+	}
+
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+
+	return filter
+}
+
+func categoryCondition(categories []string) any {
+	switch len(categories) {
+	case 0:
+		return bson.M{"$exists": true}
+	case 1:
+		return categories[0]
+	default:
+		return bson.M{"$in": categories}
+	}
+}
+
+func containsAny(field string, terms []string) bson.M {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+
+	return bson.M{field: bson.M{
+		"$regex":   strings.Join(quoted, "|"),
+		"$options": "i",
+	}}
+}
+
+func equalsAny(field string, terms []string) bson.M {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+
+	return bson.M{field: bson.M{
+		"$regex":   "^(" + strings.Join(quoted, "|") + ")$",
+		"$options": "i",
+	}}
+}
+
+func fieldCondition(f types.Filter) bson.M {
+	if field, ok := weaponfields.Lookup(f.Field); ok && !field.Text {
+		if value, err := strconv.ParseFloat(f.Value, 64); err == nil {
+			return bson.M{statValueField(f.Field): bson.M{numericOperators[f.Operator]: value}}
+		}
+	}
+
+	match := bson.M{
+		"$regex":   "^" + regexp.QuoteMeta(f.Value) + "$",
+		"$options": "i",
+	}
+
+	if f.Operator == types.OpNe {
+		return bson.M{fieldPath(f.Field): bson.M{"$not": match}}
+	}
+
+	return bson.M{fieldPath(f.Field): match}
+}
+
+func weaponsSort(query types.WeaponsQuery) bson.D {
+	sort := make(bson.D, 0, len(query.Sort)*2)
+
+	for _, s := range query.Sort {
+		direction := 1
+		if s.Desc {
+			direction = -1
+		}
+
+		sort = append(sort,
+			bson.E{Key: statValueField(s.Field), Value: direction},
+			bson.E{Key: fieldPath(s.Field), Value: direction},
+		)
+	}
+
+	if query.Page.Limit > 0 || query.Page.Offset > 0 {
+		sort = append(sort, bson.E{Key: FieldWeaponID, Value: 1})
+	}
+
+	return sort
+}
+
+func weaponsProjection(query types.WeaponsQuery) bson.M {
+	if len(query.Fields) == 0 {
+		return nil
+	}
+
+	projection := bson.M{
+		FieldWeaponID:        1,
+		FieldWeaponsCategory: 1,
+		FieldWeaponName:      1,
+	}
+
+	for _, field := range query.Fields {
+		projection[fieldPath(field)] = 1
+		projection[FieldStats+"."+field] = 1
+		projection[FieldQuantities+"."+units.Canonical(field)] = 1
+	}
+
+	return projection
+}
+
+func applyPage(opts *options.FindOptionsBuilder, page types.Page) {
+	if page.Offset > 0 {
+		opts.SetSkip(int64(page.Offset))
+	}
+	if page.Limit > 0 {
+		opts.SetLimit(int64(page.Limit))
+	}
+}
@@ -0,0 +1,129 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/erknas/wt-guided-weapons/internal/types"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestWeaponsFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query types.WeaponsQuery
+		want  bson.M
+	}{
+		{
+			name:  "category only",
+			query: types.WeaponsQuery{Categories: []string{"aam-ir-all-aspect"}},
+			want:  bson.M{"category": "aam-ir-all-aspect", "retired": bson.M{"$ne": true}},
+		},
+		{
+			name:  "any category",
+			query: types.WeaponsQuery{},
+			want:  bson.M{"category": bson.M{"$exists": true}, "retired": bson.M{"$ne": true}},
+		},
+		{
+			name:  "include retired",
+			query: types.WeaponsQuery{Categories: []string{"aam-arh"}, IncludeRetired: true},
+			want:  bson.M{"category": "aam-arh"},
+		},
+		{
+			name: "several categories guidance and warhead",
+			query: types.WeaponsQuery{
+				Categories: []string{"aam-arh", "sam-arh"},
+				Guidance:   []string{"radar", "ARH"},
+				Warheads:   []string{"HE"},
+			},
+			want: bson.M{
+				"category": bson.M{"$in": []string{"aam-arh", "sam-arh"}},
+				"retired":  bson.M{"$ne": true},
+				"$and": bson.A{
+					bson.M{"guidance_type": bson.M{"$regex": "radar|ARH", "$options": "i"}},
+					bson.M{"warhead": bson.M{"$regex": "^(HE)$", "$options": "i"}},
+				},
+			},
+		},
+		{
+			name: "numeric and text filters",
+			query: types.WeaponsQuery{
+				Categories: []string{"aam-ir-all-aspect"},
+				Filters: []types.Filter{
+					{Field: "maximum_g_load", Operator: types.OpGt, Value: "30"},
+					{Field: "irccm", Operator: types.OpEq, Value: "Yes"},
+					{Field: "band", Operator: types.OpNe, Value: "J"},
+				},
+			},
+			want: bson.M{
+				"category": "aam-ir-all-aspect",
+				"retired":  bson.M{"$ne": true},
+				"$and": bson.A{
+					bson.M{"stats.maximum_g_load.value": bson.M{"$gt": 30.0}},
+					bson.M{"irccm": bson.M{"$regex": "^Yes$", "$options": "i"}},
+					bson.M{"band": bson.M{"$not": bson.M{"$regex": "^J$", "$options": "i"}}},
+				},
+			},
+		},
+		{
+			name: "numeric value on text field",
+			query: types.WeaponsQuery{
+				Categories: []string{"aam-arh"},
+				Filters:    []types.Filter{{Field: "name", Operator: types.OpEq, Value: "9"}},
+			},
+			want: bson.M{
+				"category": "aam-arh",
+				"retired":  bson.M{"$ne": true},
+				"$and": bson.A{
+					bson.M{"name": bson.M{"$regex": "^9$", "$options": "i"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, weaponsFilter(tt.query))
+		})
+	}
+}
+
+func TestWeaponsSort(t *testing.T) {
+	query := types.WeaponsQuery{
+		Sort: []types.SortField{
+			{Field: "maximum_g_load", Desc: true},
+			{Field: "name"},
+		},
+	}
+
+	want := bson.D{
+		{Key: "stats.maximum_g_load.value", Value: -1},
+		{Key: "maximum_g_load", Value: -1},
+		{Key: "stats.name.value", Value: 1},
+		{Key: "name", Value: 1},
+	}
+
+	assert.Equal(t, want, weaponsSort(query))
+	assert.Empty(t, weaponsSort(types.WeaponsQuery{}))
+
+	paged := weaponsSort(types.WeaponsQuery{Page: types.Page{Limit: 10}})
+	assert.Equal(t, bson.D{{Key: "id", Value: 1}}, paged)
+}
+
+func TestWeaponsProjection(t *testing.T) {
+	assert.Nil(t, weaponsProjection(types.WeaponsQuery{}))
+
+	projection := weaponsProjection(types.WeaponsQuery{Fields: []string{"mass"}})
+	assert.Equal(t, bson.M{
+		"id":              1,
+		"category":        1,
+		"name":            1,
+		"mass":            1,
+		"stats.mass":      1,
+		"quantities.mass": 1,
+	}, projection)
+
+	projection = weaponsProjection(types.WeaponsQuery{Fields: []string{"minimum_range_km"}})
+	assert.Contains(t, projection, "stats.minimum_range_km")
+	assert.Contains(t, projection, "quantities.minimum_range")
+}
@@ -0,0 +1,14 @@
+package types
+
+type Category struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Family      string `json:"family,omitempty"`
+	Guidance    string `json:"guidance,omitempty"`
+	Count       int    `json:"count"`
+	LastUpdated string `json:"last_updated,omitempty"`
+}
+
+type Categories struct {
+	Categories []Category `json:"categories"`
+}
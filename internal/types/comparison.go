@@ -0,0 +1,13 @@
+package types
+
+type Comparison struct {
+	Weapons []WeaponRef     `json:"weapons"`
+	Fields  []ComparedField `json:"fields"`
+}
+
+type ComparedField struct {
+	Field  string   `json:"field"`
+	Unit   string   `json:"unit,omitempty"`
+	Values []string `json:"values"`
+	Best   []string `json:"best,omitempty"`
+}
@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+type CategorySchema struct {
+	Category string   `json:"category" bson:"category"`
+	Labels   []string `json:"-" bson:"labels"`
+	Rows     int      `json:"rows" bson:"rows"`
+	Known    int      `json:"known" bson:"known"`
+	Coverage float64  `json:"coverage" bson:"coverage"`
+	Unknown  []string `json:"unknown,omitempty" bson:"unknown,omitempty"`
+	Missing  []string `json:"missing,omitempty" bson:"missing,omitempty"`
+}
+
+type SchemaReport struct {
+	Version    string           `json:"version" bson:"_id"`
+	CreatedAt  time.Time        `json:"created_at" bson:"created_at"`
+	Unmatched  []string         `json:"unmatched,omitempty" bson:"unmatched,omitempty"`
+	Categories []CategorySchema `json:"categories" bson:"categories"`
+}
@@ -1,10 +1,13 @@
 package types
 
 type SearchResult struct {
-	Name     string `json:"name"`
-	Category string `json:"category"`
+	ID       string  `json:"id" bson:"id"`
+	Name     string  `json:"name" bson:"name"`
+	Category string  `json:"category" bson:"category"`
+	Score    float64 `json:"score,omitempty" bson:"-"`
 }
 
 type SearchResults struct {
-	Results []SearchResult `json:"results"`
+	Results    []SearchResult `json:"results"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,5 @@
+package types
+
+type Suggestions struct {
+	Suggestions []WeaponRef `json:"suggestions"`
+}
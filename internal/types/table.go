@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Table struct {
+	URL      string        `json:"url"`
+	Title    string        `json:"title,omitempty"`
+	Family   string        `json:"family,omitempty"`
+	Guidance string        `json:"guidance,omitempty"`
+	Refresh  time.Duration `json:"-"`
+	Priority int           `json:"priority,omitempty"`
+}
+
+func (t *Table) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*t = Table{URL: url}
+		return nil
+	}
+
+	type table Table
+
+	var raw struct {
+		table
+		Refresh string `json:"refresh,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*t = Table(raw.table)
+
+	if raw.Refresh != "" {
+		refresh, err := time.ParseDuration(raw.Refresh)
+		if err != nil {
+			return fmt.Errorf("invalid refresh %q: %w", raw.Refresh, err)
+		}
+		t.Refresh = refresh
+	}
+
+	return nil
+}
+
+type Tables map[string]Table
+
+func (t Tables) URLs() map[string]string {
+	urls := make(map[string]string, len(t))
+
+	for category, table := range t {
+		urls[category] = table.URL
+	}
+
+	return urls
+}
@@ -0,0 +1,32 @@
+package types
+
+const (
+	CategoryStatusOK        = "ok"
+	CategoryStatusFailed    = "failed"
+	CategoryStatusUnchanged = "unchanged"
+)
+
+type UpdateRequest struct {
+	Partial    bool
+	Categories []string
+	Trigger    string
+}
+
+type CategoryReport struct {
+	Category string   `json:"category"`
+	Status   string   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+	Weapons  int      `json:"weapons"`
+	Hash     string   `json:"hash,omitempty"`
+	Labels   []string `json:"-"`
+}
+
+type UpdateResult struct {
+	Version    string           `json:"version"`
+	Weapons    int              `json:"weapons"`
+	Retries    int              `json:"retries"`
+	Retired    int              `json:"retired,omitempty"`
+	Merged     int              `json:"merged,omitempty"`
+	Partial    bool             `json:"partial"`
+	Categories []CategoryReport `json:"categories,omitempty"`
+}
@@ -0,0 +1,15 @@
+package types
+
+type VersionDiff struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Category string          `json:"category,omitempty"`
+	Added    []WeaponRef     `json:"added"`
+	Removed  []WeaponRef     `json:"removed"`
+	Changed  []WeaponChanges `json:"changed"`
+}
+
+type WeaponChanges struct {
+	WeaponRef
+	Changes []FieldChange `json:"changes"`
+}
@@ -1,9 +1,28 @@
 package types
 
+import "time"
+
+const (
+	TriggerStartup   = "startup"
+	TriggerVersion   = "version"
+	TriggerContent   = "content"
+	TriggerManual    = "manual"
+	TriggerMigration = "migration"
+)
+
 type VersionInfo struct {
 	Version string `json:"version" bson:"version"`
 }
 
 type LastChange struct {
-	Version VersionInfo
+	ID           string `json:"-" bson:"_id,omitempty"`
+	VersionInfo  `bson:",inline"`
+	IngestedAt   time.Time         `json:"ingested_at" bson:"ingested_at"`
+	DurationMs   int64             `json:"duration_ms" bson:"duration_ms"`
+	Trigger      string            `json:"trigger" bson:"trigger"`
+	Partial      bool              `json:"partial" bson:"partial"`
+	Weapons      int               `json:"weapons" bson:"weapons"`
+	Categories   map[string]int    `json:"categories,omitempty" bson:"categories,omitempty"`
+	SourceHashes map[string]string `json:"source_hashes,omitempty" bson:"source_hashes,omitempty"`
+	Pending      bool              `json:"-" bson:"pending,omitempty"`
 }
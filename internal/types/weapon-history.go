@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+type WeaponSnapshot struct {
+	WeaponID   string    `json:"weapon_id" bson:"weapon_id"`
+	Version    string    `json:"version" bson:"version"`
+	RecordedAt time.Time `json:"recorded_at" bson:"recorded_at"`
+	Weapon     *Weapon   `json:"weapon" bson:"weapon"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+type HistoryEntry struct {
+	Version    string        `json:"version"`
+	RecordedAt time.Time     `json:"recorded_at"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+type WeaponHistory struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Category string         `json:"category"`
+	History  []HistoryEntry `json:"history"`
+}
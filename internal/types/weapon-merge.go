@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+const (
+	MatchColumn    = "column"
+	MatchName      = "name"
+	MatchFuzzyName = "fuzzy_name"
+	MatchStats     = "stats"
+)
+
+type WeaponMerge struct {
+	WeaponID     string    `json:"weapon_id" bson:"weapon_id"`
+	Alias        string    `json:"alias" bson:"alias"`
+	Version      string    `json:"version" bson:"version"`
+	FromName     string    `json:"from_name" bson:"from_name"`
+	ToName       string    `json:"to_name" bson:"to_name"`
+	FromCategory string    `json:"from_category" bson:"from_category"`
+	ToCategory   string    `json:"to_category" bson:"to_category"`
+	Score        float64   `json:"score" bson:"score"`
+	Reasons      []string  `json:"reasons" bson:"reasons"`
+	MergedAt     time.Time `json:"merged_at" bson:"merged_at"`
+}
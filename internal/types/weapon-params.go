@@ -1,166 +1,174 @@
 package types
 
 type Weapons struct {
-	Weapons []*Weapon `json:"weapons"`
+	Weapons    []*Weapon `json:"weapons"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
 
 type Weapon struct {
-	ID                                      string `json:"id" bson:"id"`
-	Category                                string `json:"category,omitempty" bson:"category,omitempty"`
-	Name                                    string `json:"name,omitempty" bson:"name,omitempty"`
-	Mass                                    string `json:"mass,omitempty" bson:"mass,omitempty"`
-	MassAtEndOfBoosterBurn                  string `json:"mass_at_end_of_booster_burn,omitempty" bson:"mass_at_end_of_booster_burn,omitempty"`
-	MassAtEndOfSustainerBurn                string `json:"mass_at_end_of_sustainer_burn,omitempty" bson:"mass_at_end_of_sustainer_burn,omitempty"`
-	Caliber                                 string `json:"caliber,omitempty" bson:"caliber,omitempty"`
-	Length                                  string `json:"length,omitempty" bson:"length,omitempty"`
-	ForceExertedByBooster                   string `json:"force_exerted_by_booster,omitempty" bson:"force_exerted_by_booster,omitempty"`
-	BurnTimeOfBooster                       string `json:"burn_time_of_booster,omitempty" bson:"burn_time_of_booster,omitempty"`
-	RawAccelerationAtIgnition               string `json:"raw_acceleration_at_ignition,omitempty" bson:"raw_acceleration_at_ignition,omitempty"`
-	SpecificImpulseOfBooster                string `json:"specific_impulse_of_booster,omitempty" bson:"specific_impulse_of_booster,omitempty"`
-	DeltaVOfBooster                         string `json:"delta_v_of_booster,omitempty" bson:"delta_v_of_booster,omitempty"`
-	BoosterStartDelay                       string `json:"booster_start_delay,omitempty" bson:"booster_start_delay,omitempty"`
-	ForceExertedBySustainer                 string `json:"force_exerted_by_sustainer,omitempty" bson:"force_exerted_by_sustainer,omitempty"`
-	BurnTimeOfSustainer                     string `json:"burn_time_of_sustainer,omitempty" bson:"burn_time_of_sustainer,omitempty"`
-	SpecificImpulseOfSustainer              string `json:"specific_impulse_of_sustainer,omitempty" bson:"specific_impulse_of_sustainer,omitempty"`
-	DeltaVOfSustainer                       string `json:"delta_v_of_sustainer,omitempty" bson:"delta_v_of_sustainer,omitempty"`
-	TotalDeltaV                             string `json:"total_delta_v,omitempty" bson:"total_delta_v,omitempty"`
-	ExplosiveMass                           string `json:"explosive_mass,omitempty" bson:"explosive_mass,omitempty"`
-	Warhead                                 string `json:"warhead,omitempty" bson:"warhead,omitempty"`
-	Penetration                             string `json:"penetration,omitempty" bson:"penetration,omitempty"`
-	ProximityFuse                           string `json:"proximity_fuse,omitempty" bson:"proximity_fuse,omitempty"`
-	ProximityFuseArmingDistance             string `json:"proximity_fuse_arming_distance,omitempty" bson:"proximity_fuse_arming_distance,omitempty"`
-	ProximityFuseArmingDistanceFromTarget   string `json:"proximity_fuse_arming_distance_from_target,omitempty" bson:"proximity_fuse_arming_distance_from_target,omitempty"`
-	ProximityFuseRange                      string `json:"proximity_fuse_range,omitempty" bson:"proximity_fuse_range,omitempty"`
-	ProximityFuseShellDetection             string `json:"proximity_fuse_shell_detection,omitempty" bson:"proximity_fuse_shell_detection,omitempty"`
-	ProximityFuseMinimumAltitude            string `json:"proximity_fuse_minimum_altitude,omitempty" bson:"proximity_fuse_minimum_altitude,omitempty"`
-	ProximityFuseDelay                      string `json:"proximity_fuse_delay,omitempty" bson:"proximity_fuse_delay,omitempty"`
-	ImpactFuseSensitivity                   string `json:"impact_fuse_sensitivity,omitempty" bson:"impact_fuse_sensitivity,omitempty"`
-	ImpactFuseDelay                         string `json:"impact_fuse_delay,omitempty" bson:"impact_fuse_delay,omitempty"`
-	DefaultZoom                             string `json:"default_zoom,omitempty" bson:"default_zoom,omitempty"`
-	GuidanceType                            string `json:"guidance_type,omitempty" bson:"guidance_type,omitempty"`
-	GuidanceStartDelay                      string `json:"guidance_start_delay,omitempty" bson:"guidance_start_delay,omitempty"`
-	GuidanceDuration                        string `json:"guidance_duration,omitempty" bson:"guidance_duration,omitempty"`
-	GuidanceRange                           string `json:"guidance_range,omitempty" bson:"guidance_range,omitempty"`
-	GuidanceFOV                             string `json:"guidance_fov,omitempty" bson:"guidance_fov,omitempty"`
-	GuidanceMaxLead                         string `json:"guidance_max_lead,omitempty" bson:"guidance_max_lead,omitempty"`
-	LaunchSector                            string `json:"launch_sector,omitempty" bson:"launch_sector,omitempty"`
-	GuidanceLaunchSector                    string `json:"guidance_launch_sector,omitempty" bson:"guidance_launch_sector,omitempty"`
-	AimTrackingSensitivity                  string `json:"aim_tracking_sensitivity,omitempty" bson:"aim_tracking_sensitivity,omitempty"`
-	SeekerWarmUpTime                        string `json:"seeker_warm_up_time,omitempty" bson:"seeker_warm_up_time,omitempty"`
-	SeekerSearchDuration                    string `json:"seeker_search_duration,omitempty" bson:"seeker_search_duration,omitempty"`
-	SeekerRange                             string `json:"seeker_range,omitempty" bson:"seeker_range,omitempty"`
-	FieldOfView                             string `json:"field_of_view,omitempty" bson:"field_of_view,omitempty"`
-	GimbalLimit                             string `json:"gimbal_limit,omitempty" bson:"gimbal_limit,omitempty"`
-	TrackRate                               string `json:"track_rate,omitempty" bson:"track_rate,omitempty"`
-	UncagedSeekerBeforeLaunch               string `json:"uncaged_seeker_before_launch,omitempty" bson:"uncaged_seeker_before_launch,omitempty"`
-	MaxLockAngleBeforeLaunch                string `json:"max_lock_angle_before_launch,omitempty" bson:"max_lock_angle_before_launch,omitempty"`
-	MinAngleOfIncidenceToSun                string `json:"min_angle_of_incidence_to_sun,omitempty" bson:"min_angle_of_incidence_to_sun,omitempty"`
-	BaselineLockRangeRear                   string `json:"baseline_lock_range_rear,omitempty" bson:"baseline_lock_range_rear,omitempty"`
-	BaselineFlareAndIRCMDetectionRange      string `json:"baseline_flare_and_ircm_detection_range,omitempty" bson:"baseline_flare_and_ircm_detection_range,omitempty"`
-	BaselineLockRangeAll                    string `json:"baseline_lock_range_all,omitempty" bson:"baseline_lock_range_all,omitempty"`
-	BaselineLockRangeGround                 string `json:"baseline_lock_range_ground,omitempty" bson:"baseline_lock_range_ground,omitempty"`
-	BaselineLockRangeTarget                 string `json:"baseline_lock_range_target,omitempty" bson:"baseline_lock_range_target,omitempty"`
-	BaselineFlareDetection                  string `json:"baseline_flare_detection,omitempty" bson:"baseline_flare_detection,omitempty"`
-	BaselineIRCMDetection                   string `json:"baseline_ircm_detection,omitempty" bson:"baseline_ircm_detection,omitempty"`
-	BaselineDIRCMDetection                  string `json:"baseline_dircm_detection,omitempty" bson:"baseline_dircm_detection,omitempty"`
-	BaselineLDIRCMDetection                 string `json:"baseline_ldircm_detection,omitempty" bson:"baseline_ldircm_detection,omitempty"`
-	BaselineHeadOnLockRange                 string `json:"baseline_head_on_lock_range,omitempty" bson:"baseline_head_on_lock_range,omitempty"`
-	MaxLockRangeHardLimit                   string `json:"max_lock_range,omitempty" bson:"max_lock_range,omitempty"`
-	IRCCM                                   string `json:"irccm,omitempty" bson:"irccm,omitempty"`
-	IRCCMType                               string `json:"irccm_type,omitempty" bson:"irccm_type,omitempty"`
-	IRCCMFieldOfView                        string `json:"irccm_field_of_view,omitempty" bson:"irccm_field_of_view,omitempty"`
-	IRCCMRejectionThreshold                 string `json:"irccm_rejection_threshold,omitempty" bson:"irccm_rejection_threshold,omitempty"`
-	IRCCMReactionTime                       string `json:"irccm_reaction_time,omitempty" bson:"irccm_reaction_time,omitempty"`
-	MinTargetSize                           string `json:"min_target_size,omitempty" bson:"min_target_size,omitempty"`
-	MaxBreakLockTime                        string `json:"max_break_lock_time,omitempty" bson:"max_break_lock_time,omitempty"`
-	CanBeSlavedToRadar                      string `json:"can_be_slaved_to_radar,omitempty" bson:"can_be_slaved_to_radar,omitempty"`
-	CanLockAfterLaunch                      string `json:"can_lock_after_launch,omitempty" bson:"can_lock_after_launch,omitempty"`
-	Band                                    string `json:"band,omitempty" bson:"band,omitempty"`
-	AngularSpeedRejectionThresh             string `json:"angular_speed_rejection,omitempty" bson:"angular_speed_rejection,omitempty"`
-	AccelRejectionThreshRange               string `json:"accel_rejection,omitempty" bson:"accel_rejection,omitempty"`
-	InertialGuidanceDriftSpeedMs            string `json:"inertial_guidance_drift_ms,omitempty" bson:"inertial_guidance_drift_ms,omitempty"`
-	InertialGuidanceDriftSpeed              string `json:"inertial_guidance_drift,omitempty" bson:"inertial_guidance_drift,omitempty"`
-	Datalink                                string `json:"datalink,omitempty" bson:"datalink,omitempty"`
-	CanDatalinkReconnect                    string `json:"can_datalink_reconnect,omitempty" bson:"can_datalink_reconnect,omitempty"`
-	SidelobeAttenuation                     string `json:"sidelobe_attenuation,omitempty" bson:"sidelobe_attenuation,omitempty"`
-	TransmitterPower                        string `json:"transmitter_power,omitempty" bson:"transmitter_power,omitempty"`
-	TransmitterHalfSensitivity              string `json:"transmitter_half_sensitivity,omitempty" bson:"transmitter_half_sensitivity,omitempty"`
-	TransmitterSidelobeSens                 string `json:"transmitter_sidelobe_sensitivity,omitempty" bson:"transmitter_sidelobe_sensitivity,omitempty"`
-	ReceiverHalfSensitivity                 string `json:"receiver_half_sensitivity,omitempty" bson:"receiver_half_sensitivity,omitempty"`
-	ReceiverSidelobeSens                    string `json:"receiver_sidelobe_sensitivity,omitempty" bson:"receiver_sidelobe_sensitivity,omitempty"`
-	DistanceMinValue                        string `json:"distance_min,omitempty" bson:"distance_min,omitempty"`
-	DistanceMaxValue                        string `json:"distance_max,omitempty" bson:"distance_max,omitempty"`
-	DistanceWidth                           string `json:"distance_width,omitempty" bson:"distance_width,omitempty"`
-	DistanceRefWidth                        string `json:"distance_ref_width,omitempty" bson:"distance_ref_width,omitempty"`
-	DistanceMinSignalGate                   string `json:"distance_min_signal_gate,omitempty" bson:"distance_min_signal_gate,omitempty"`
-	DistanceMinValueM                       string `json:"distance_min_m,omitempty" bson:"distance_min_m,omitempty"`
-	DistanceMaxValueKm                      string `json:"distance_max_km,omitempty" bson:"distance_max_km,omitempty"`
-	DistanceWidthM                          string `json:"distance_width_m,omitempty" bson:"distance_width_m,omitempty"`
-	DistanceRefWidthM                       string `json:"distance_ref_width_m,omitempty" bson:"distance_ref_width_m,omitempty"`
-	DistanceMinSignalGateM                  string `json:"distance_min_signal_gate_m,omitempty" bson:"distance_min_signal_gate_m,omitempty"`
-	DistanceGateSearchRange                 string `json:"distance_gate_search,omitempty" bson:"distance_gate_search,omitempty"`
-	DistanceGateAlphaFilter                 string `json:"distance_gate_alpha,omitempty" bson:"distance_gate_alpha,omitempty"`
-	DistanceGateBetaFilter                  string `json:"distance_gate_beta,omitempty" bson:"distance_gate_beta,omitempty"`
-	DopplerSpeedMinValue                    string `json:"doppler_speed_min,omitempty" bson:"doppler_speed_min,omitempty"`
-	DopplerSpeedMaxValue                    string `json:"doppler_speed_max,omitempty" bson:"doppler_speed_max,omitempty"`
-	DopplerSpeedWidth                       string `json:"doppler_speed_width,omitempty" bson:"doppler_speed_width,omitempty"`
-	DopplerSpeedRefWidth                    string `json:"doppler_speed_ref_width,omitempty" bson:"doppler_speed_ref_width,omitempty"`
-	DopplerSpeedMinSignalGate               string `json:"doppler_speed_min_gate,omitempty" bson:"doppler_speed_min_gate,omitempty"`
-	DopplerSpeedGateSearch                  string `json:"doppler_speed_gate_search,omitempty" bson:"doppler_speed_gate_search,omitempty"`
-	DopplerSpeedGateAlpha                   string `json:"doppler_speed_gate_alpha,omitempty" bson:"doppler_speed_gate_alpha,omitempty"`
-	DopplerSpeedGateBeta                    string `json:"doppler_speed_gate_beta,omitempty" bson:"doppler_speed_gate_beta,omitempty"`
-	ProportionalNavMultiplier               string `json:"proportional_nav_multiplier,omitempty" bson:"proportional_nav_multiplier,omitempty"`
-	BaseIndicatedAirSpeed                   string `json:"base_air_speed,omitempty" bson:"base_air_speed,omitempty"`
-	PIDProportionalTerm                     string `json:"pid_proportional,omitempty" bson:"pid_proportional,omitempty"`
-	PIDIntegralTerm                         string `json:"pid_integral,omitempty" bson:"pid_integral,omitempty"`
-	PIDIntegralTermLimit                    string `json:"pid_integral_limit,omitempty" bson:"pid_integral_limit,omitempty"`
-	PIDDerivativeTerm                       string `json:"pid_derivative,omitempty" bson:"pid_derivative,omitempty"`
-	OrientingPhase                          string `json:"orienting_phase,omitempty" bson:"orienting_phase,omitempty"`
-	OrientingStartDelay                     string `json:"orienting_start_delay,omitempty" bson:"orienting_start_delay,omitempty"`
-	OrientingControlTime                    string `json:"orienting_control_time,omitempty" bson:"orienting_control_time,omitempty"`
-	OrientingElevationAddition              string `json:"orienting_elevation_addition,omitempty" bson:"orienting_elevation_addition,omitempty"`
-	DragCoefficientMultiplier               string `json:"drag_coefficient_multiplier,omitempty" bson:"drag_coefficient_multiplier,omitempty"`
-	DragCoefficientMultiplierBomb           string `json:"drag_coefficient_multiplier_bomb,omitempty" bson:"drag_coefficient_multiplier_bomb,omitempty"`
-	WingAreaMultiplier                      string `json:"wing_area_multiplier,omitempty" bson:"wing_area_multiplier,omitempty"`
-	StartSpeed                              string `json:"start_speed,omitempty" bson:"start_speed,omitempty"`
-	MaximumSpeed                            string `json:"maximum_speed,omitempty" bson:"maximum_speed,omitempty"`
-	MinimumRange                            string `json:"minimum_range,omitempty" bson:"minimum_range,omitempty"`
-	MinimumRangeKm                          string `json:"minimum_range_km,omitempty" bson:"minimum_range_km,omitempty"`
-	FlightRangeLimit                        string `json:"flight_range_limit,omitempty" bson:"flight_range_limit,omitempty"`
-	MaximumGLoad                            string `json:"maximum_g_load,omitempty" bson:"maximum_g_load,omitempty"`
-	MaximumFinAngleOfAttack                 string `json:"maximum_fin_angle_of_attack,omitempty" bson:"maximum_fin_angle_of_attack,omitempty"`
-	MaximumFinLateralAcceleration           string `json:"maximum_fin_lateral_acceleration,omitempty" bson:"maximum_fin_lateral_acceleration,omitempty"`
-	FinsLateralAcceleration                 string `json:"fins_lateral_acceleration,omitempty" bson:"fins_lateral_acceleration,omitempty"`
-	MaximumLateralAcceleration              string `json:"maximum_lateral_acceleration,omitempty" bson:"maximum_lateral_acceleration,omitempty"`
-	MaxLateralAcceleration                  string `json:"max_lateral_acceleration,omitempty" bson:"max_lateral_acceleration,omitempty"`
-	MaximumAOA                              string `json:"maximum_aoa,omitempty" bson:"maximum_aoa,omitempty"`
-	ThrustVectoring                         string `json:"thrust_vectoring,omitempty" bson:"thrust_vectoring,omitempty"`
-	ThrustVectoringAngle                    string `json:"thrust_vectoring_angle,omitempty" bson:"thrust_vectoring_angle,omitempty"`
-	ThrustVectoringAngles                   string `json:"thrust_vectoring_angles,omitempty" bson:"thrust_vectoring_angles,omitempty"`
-	MaximumLaunchAngleHorizontalVertical    string `json:"maximum_launch_angle_horizontal_vertical,omitempty" bson:"maximum_launch_angle_horizontal_vertical,omitempty"`
-	MaximumLaunchAngle                      string `json:"maximum_launch_angle,omitempty" bson:"maximum_launch_angle,omitempty"`
-	MaximumAxisValues                       string `json:"maximum_axis_values,omitempty" bson:"maximum_axis_values,omitempty"`
-	StatcardSpeedMach                       string `json:"statcard_speed_mach,omitempty" bson:"statcard_speed_mach,omitempty"`
-	StatcardSpeedMs                         string `json:"statcard_speed_ms,omitempty" bson:"statcard_speed_ms,omitempty"`
-	StatcardSpeedMsOrMach                   string `json:"statcard_speed_ms_or_mach,omitempty" bson:"statcard_speed_ms_or_mach,omitempty"`
-	StatcardLaunchRange                     string `json:"statcard_launch_range,omitempty" bson:"statcard_launch_range,omitempty"`
-	StatcardGuaranteedRange                 string `json:"statcard_guaranteed_range,omitempty" bson:"statcard_guaranteed_range,omitempty"`
-	MaximumStatcardGLoad                    string `json:"maximum_statcard_g_load,omitempty" bson:"maximum_statcard_g_load,omitempty"`
-	StatcardMaxGLoad                        string `json:"statcard_max_g_load,omitempty" bson:"statcard_max_g_load,omitempty"`
-	FlightTimeUntilGuidanceStarts           string `json:"flight_time_until_guidance_starts,omitempty" bson:"flight_time_until_guidance_starts,omitempty"`
-	FlightTimeWhenPullLimitX                string `json:"flight_time_when_pull_limit_x,omitempty" bson:"flight_time_when_pull_limit_x,omitempty"`
-	FlightTimeWhenPullLimit100              string `json:"flight_time_when_pull_limit_100,omitempty" bson:"flight_time_when_pull_limit_100,omitempty"`
-	ETAtoImpactWhenPropMultiplier           string `json:"eta_to_impact_when_prop_multiplier,omitempty" bson:"eta_to_impact_when_prop_multiplier,omitempty"`
-	Loft                                    string `json:"loft,omitempty" bson:"loft,omitempty"`
-	LoftAngle                               string `json:"loft_angle,omitempty" bson:"loft_angle,omitempty"`
-	LoftA                                   string `json:"loft_a,omitempty" bson:"loft_a,omitempty"`
-	TargetElevation                         string `json:"target_elevation,omitempty" bson:"target_elevation,omitempty"`
-	TargetE                                 string `json:"target_e,omitempty" bson:"target_e,omitempty"`
-	MaximumTargetAngularChange              string `json:"maximum_target_angular_change,omitempty" bson:"maximum_target_angular_change,omitempty"`
-	HasTracerInTail                         string `json:"has_tracer_in_tail,omitempty" bson:"has_tracer_in_tail,omitempty"`
-	SeaSkimming                             string `json:"sea_skimming,omitempty" bson:"sea_skimming,omitempty"`
-	ETAtoImpactWhenSeaAltitudeReachesMetres string `json:"eta_to_impact_when_sea_altitude_reaches_metres,omitempty" bson:"eta_to_impact_when_sea_altitude_reaches_metres,omitempty"`
-	SkimAltitude                            string `json:"skim_altitude,omitempty" bson:"skim_altitude,omitempty"`
-	AttackAltitude                          string `json:"attack_altitude,omitempty" bson:"attack_altitude,omitempty"`
-	AdditionalNotes                         string `json:"additional_notes,omitempty" bson:"additional_notes,omitempty"`
+	ID                                      string            `json:"id" bson:"id"`
+	Category                                string            `json:"category,omitempty" bson:"category,omitempty"`
+	Name                                    string            `json:"name,omitempty" bson:"name,omitempty" kind:"text" sheet:"Name:"`
+	Mass                                    string            `json:"mass,omitempty" bson:"mass,omitempty" unit:"kg" sheet:"Mass: [kg]"`
+	MassAtEndOfBoosterBurn                  string            `json:"mass_at_end_of_booster_burn,omitempty" bson:"mass_at_end_of_booster_burn,omitempty" unit:"kg" sheet:"Mass at end of booster burn: [kg]"`
+	MassAtEndOfSustainerBurn                string            `json:"mass_at_end_of_sustainer_burn,omitempty" bson:"mass_at_end_of_sustainer_burn,omitempty" unit:"kg" sheet:"Mass at end of sustainer burn: [kg]"`
+	Caliber                                 string            `json:"caliber,omitempty" bson:"caliber,omitempty" unit:"mm" sheet:"Calibre: [mm]"`
+	Length                                  string            `json:"length,omitempty" bson:"length,omitempty" unit:"m" sheet:"Length: [m]"`
+	ForceExertedByBooster                   string            `json:"force_exerted_by_booster,omitempty" bson:"force_exerted_by_booster,omitempty" unit:"N" sheet:"Force exerted by booster: [N]"`
+	BurnTimeOfBooster                       string            `json:"burn_time_of_booster,omitempty" bson:"burn_time_of_booster,omitempty" unit:"s" sheet:"Burn time of booster: [s]"`
+	RawAccelerationAtIgnition               string            `json:"raw_acceleration_at_ignition,omitempty" bson:"raw_acceleration_at_ignition,omitempty" unit:"m/s²" sheet:"Raw acceleration at ignition: [m/s²]"`
+	SpecificImpulseOfBooster                string            `json:"specific_impulse_of_booster,omitempty" bson:"specific_impulse_of_booster,omitempty" unit:"s" sheet:"Specific impulse of booster: [s]"`
+	DeltaVOfBooster                         string            `json:"delta_v_of_booster,omitempty" bson:"delta_v_of_booster,omitempty" unit:"m/s" sheet:"ΔV of booster: [m/s]"`
+	BoosterStartDelay                       string            `json:"booster_start_delay,omitempty" bson:"booster_start_delay,omitempty" unit:"s" sheet:"Booster start delay: [s]"`
+	ForceExertedBySustainer                 string            `json:"force_exerted_by_sustainer,omitempty" bson:"force_exerted_by_sustainer,omitempty" unit:"N" sheet:"Force exerted by sustainer: [N]"`
+	BurnTimeOfSustainer                     string            `json:"burn_time_of_sustainer,omitempty" bson:"burn_time_of_sustainer,omitempty" unit:"s" sheet:"Burn time of sustainer: [s]"`
+	SpecificImpulseOfSustainer              string            `json:"specific_impulse_of_sustainer,omitempty" bson:"specific_impulse_of_sustainer,omitempty" unit:"s" sheet:"Specific impulse of sustainer: [s]"`
+	DeltaVOfSustainer                       string            `json:"delta_v_of_sustainer,omitempty" bson:"delta_v_of_sustainer,omitempty" unit:"m/s" sheet:"ΔV of sustainer: [m/s]"`
+	TotalDeltaV                             string            `json:"total_delta_v,omitempty" bson:"total_delta_v,omitempty" unit:"m/s" sheet:"Total ΔV: [m/s]"`
+	ExplosiveMass                           string            `json:"explosive_mass,omitempty" bson:"explosive_mass,omitempty" unit:"kg" sheet:"Explosive mass: [kg of TNT equivalent]"`
+	Warhead                                 string            `json:"warhead,omitempty" bson:"warhead,omitempty" kind:"text" sheet:"Warhead:"`
+	Penetration                             string            `json:"penetration,omitempty" bson:"penetration,omitempty" unit:"mm" sheet:"Penetration: [mm]"`
+	ProximityFuse                           string            `json:"proximity_fuse,omitempty" bson:"proximity_fuse,omitempty" sheet:"Proximity fuze:"`
+	ProximityFuseArmingDistance             string            `json:"proximity_fuse_arming_distance,omitempty" bson:"proximity_fuse_arming_distance,omitempty" unit:"m" sheet:"Proximity fuze arming distance: [m]"`
+	ProximityFuseArmingDistanceFromTarget   string            `json:"proximity_fuse_arming_distance_from_target,omitempty" bson:"proximity_fuse_arming_distance_from_target,omitempty" unit:"m" sheet:"Proximity fuze arming distance from target: [m]"`
+	ProximityFuseRange                      string            `json:"proximity_fuse_range,omitempty" bson:"proximity_fuse_range,omitempty" unit:"m" sheet:"Proximity fuze range: [m]"`
+	ProximityFuseShellDetection             string            `json:"proximity_fuse_shell_detection,omitempty" bson:"proximity_fuse_shell_detection,omitempty" sheet:"Proximity fuze shell detection (80-200 mm):"`
+	ProximityFuseMinimumAltitude            string            `json:"proximity_fuse_minimum_altitude,omitempty" bson:"proximity_fuse_minimum_altitude,omitempty" unit:"m" sheet:"Proximity fuze minimum altitude: [m]"`
+	ProximityFuseDelay                      string            `json:"proximity_fuse_delay,omitempty" bson:"proximity_fuse_delay,omitempty" unit:"s" sheet:"Proximity fuze delay: [s]"`
+	ImpactFuseSensitivity                   string            `json:"impact_fuse_sensitivity,omitempty" bson:"impact_fuse_sensitivity,omitempty" unit:"mm" sheet:"Impact fuze sensitivity: [mm]"`
+	ImpactFuseDelay                         string            `json:"impact_fuse_delay,omitempty" bson:"impact_fuse_delay,omitempty" unit:"m" sheet:"Impact fuze delay: [m]"`
+	DefaultZoom                             string            `json:"default_zoom,omitempty" bson:"default_zoom,omitempty" sheet:"Default zoom:"`
+	GuidanceType                            string            `json:"guidance_type,omitempty" bson:"guidance_type,omitempty" kind:"text" sheet:"Guidance type:"`
+	GuidanceStartDelay                      string            `json:"guidance_start_delay,omitempty" bson:"guidance_start_delay,omitempty" unit:"s" sheet:"Guidance start delay: [s]"`
+	GuidanceDuration                        string            `json:"guidance_duration,omitempty" bson:"guidance_duration,omitempty" unit:"s" sheet:"Guidance duration: [s]"`
+	GuidanceRange                           string            `json:"guidance_range,omitempty" bson:"guidance_range,omitempty" unit:"km" sheet:"Guidance range: [km]"`
+	GuidanceFOV                             string            `json:"guidance_fov,omitempty" bson:"guidance_fov,omitempty" unit:"degrees" sheet:"Guidance FOV: [degrees]"`
+	GuidanceMaxLead                         string            `json:"guidance_max_lead,omitempty" bson:"guidance_max_lead,omitempty" unit:"degrees" sheet:"Guidance max lead: [degrees]"`
+	LaunchSector                            string            `json:"launch_sector,omitempty" bson:"launch_sector,omitempty" unit:"degrees" sheet:"Launch sector: [degrees]"`
+	GuidanceLaunchSector                    string            `json:"guidance_launch_sector,omitempty" bson:"guidance_launch_sector,omitempty" unit:"degrees" sheet:"Guidance launch sector: [degrees]"`
+	AimTrackingSensitivity                  string            `json:"aim_tracking_sensitivity,omitempty" bson:"aim_tracking_sensitivity,omitempty" sheet:"Aim tracking sensitivity:"`
+	SeekerWarmUpTime                        string            `json:"seeker_warm_up_time,omitempty" bson:"seeker_warm_up_time,omitempty" unit:"s" sheet:"Seeker warm up time: [s]"`
+	SeekerSearchDuration                    string            `json:"seeker_search_duration,omitempty" bson:"seeker_search_duration,omitempty" unit:"s" sheet:"Seeker search duration: [s]"`
+	SeekerRange                             string            `json:"seeker_range,omitempty" bson:"seeker_range,omitempty" unit:"km" sheet:"Seeker range: [km]"`
+	FieldOfView                             string            `json:"field_of_view,omitempty" bson:"field_of_view,omitempty" unit:"degrees" sheet:"Field of view: [degrees]"`
+	GimbalLimit                             string            `json:"gimbal_limit,omitempty" bson:"gimbal_limit,omitempty" unit:"degrees" sheet:"Gimbal limit: [degrees]"`
+	TrackRate                               string            `json:"track_rate,omitempty" bson:"track_rate,omitempty" unit:"degrees/s" sheet:"Track rate: [degrees/second]"`
+	UncagedSeekerBeforeLaunch               string            `json:"uncaged_seeker_before_launch,omitempty" bson:"uncaged_seeker_before_launch,omitempty" sheet:"Uncaged seeker before launch:"`
+	MaxLockAngleBeforeLaunch                string            `json:"max_lock_angle_before_launch,omitempty" bson:"max_lock_angle_before_launch,omitempty" unit:"degrees" sheet:"Maximum lock angle before launch: [degrees]"`
+	MinAngleOfIncidenceToSun                string            `json:"min_angle_of_incidence_to_sun,omitempty" bson:"min_angle_of_incidence_to_sun,omitempty" unit:"degrees" sheet:"Minimum angle of incidence of the seeker to the Sun for it to not capture the Sun: [degrees]"`
+	BaselineLockRangeRear                   string            `json:"baseline_lock_range_rear,omitempty" bson:"baseline_lock_range_rear,omitempty" unit:"km" sheet:"Baseline lock range from rear-aspect: [km]"`
+	BaselineFlareAndIRCMDetectionRange      string            `json:"baseline_flare_and_ircm_detection_range,omitempty" bson:"baseline_flare_and_ircm_detection_range,omitempty" unit:"km" sheet:"Baseline flare and baseline IRCM detection range: [km]"`
+	BaselineLockRangeAll                    string            `json:"baseline_lock_range_all,omitempty" bson:"baseline_lock_range_all,omitempty" unit:"km" sheet:"Baseline lock range from all-aspect: [km]"`
+	BaselineLockRangeGround                 string            `json:"baseline_lock_range_ground,omitempty" bson:"baseline_lock_range_ground,omitempty" unit:"km" sheet:"Baseline lock range (ground): [km]"`
+	BaselineLockRangeTarget                 string            `json:"baseline_lock_range_target,omitempty" bson:"baseline_lock_range_target,omitempty" unit:"km" sheet:"Baseline lock range (target): [km]"`
+	BaselineFlareDetection                  string            `json:"baseline_flare_detection,omitempty" bson:"baseline_flare_detection,omitempty" unit:"km" sheet:"Baseline flare detection range: [km]"`
+	BaselineIRCMDetection                   string            `json:"baseline_ircm_detection,omitempty" bson:"baseline_ircm_detection,omitempty" unit:"km" sheet:"Baseline IRCM detection range: [km]"`
+	BaselineDIRCMDetection                  string            `json:"baseline_dircm_detection,omitempty" bson:"baseline_dircm_detection,omitempty" unit:"km" sheet:"Baseline DIRCM detection range: [km]"`
+	BaselineLDIRCMDetection                 string            `json:"baseline_ldircm_detection,omitempty" bson:"baseline_ldircm_detection,omitempty" unit:"km" sheet:"Baseline LDIRCM detection range: [km]"`
+	BaselineHeadOnLockRange                 string            `json:"baseline_head_on_lock_range,omitempty" bson:"baseline_head_on_lock_range,omitempty" unit:"km" sheet:"Baseline head-on lock range against afterburning target: [km]"`
+	MaxLockRangeHardLimit                   string            `json:"max_lock_range,omitempty" bson:"max_lock_range,omitempty" unit:"km" sheet:"Maximum lock range (hard limit): [km]"`
+	IRCCM                                   string            `json:"irccm,omitempty" bson:"irccm,omitempty" sheet:"IRCCM:"`
+	IRCCMType                               string            `json:"irccm_type,omitempty" bson:"irccm_type,omitempty" kind:"text" sheet:"IRCCM type:"`
+	IRCCMFieldOfView                        string            `json:"irccm_field_of_view,omitempty" bson:"irccm_field_of_view,omitempty" unit:"degrees" sheet:"IRCCM field of view: [degrees]"`
+	IRCCMRejectionThreshold                 string            `json:"irccm_rejection_threshold,omitempty" bson:"irccm_rejection_threshold,omitempty" sheet:"IRCCM rejection treshold:|IRCCM rejection threshold:"`
+	IRCCMReactionTime                       string            `json:"irccm_reaction_time,omitempty" bson:"irccm_reaction_time,omitempty" unit:"s" sheet:"IRCCM reaction time: [s]"`
+	MinTargetSize                           string            `json:"min_target_size,omitempty" bson:"min_target_size,omitempty" unit:"m" sheet:"Minimum target size: [m]"`
+	MaxBreakLockTime                        string            `json:"max_break_lock_time,omitempty" bson:"max_break_lock_time,omitempty" unit:"s" sheet:"Maximum break lock time: [s]"`
+	CanBeSlavedToRadar                      string            `json:"can_be_slaved_to_radar,omitempty" bson:"can_be_slaved_to_radar,omitempty" sheet:"Can be slaved to radar:"`
+	CanLockAfterLaunch                      string            `json:"can_lock_after_launch,omitempty" bson:"can_lock_after_launch,omitempty" sheet:"Can lock after launch:"`
+	Band                                    string            `json:"band,omitempty" bson:"band,omitempty" kind:"text" sheet:"Band:"`
+	AngularSpeedRejectionThresh             string            `json:"angular_speed_rejection,omitempty" bson:"angular_speed_rejection,omitempty" unit:"degrees/s" sheet:"Angular speed rejection threshold: [degrees/second]"`
+	AccelRejectionThreshRange               string            `json:"accel_rejection,omitempty" bson:"accel_rejection,omitempty" unit:"m/s²" sheet:"Acceleration rejection threshold range: [m/s^2]"`
+	InertialGuidanceDriftSpeedMs            string            `json:"inertial_guidance_drift_ms,omitempty" bson:"inertial_guidance_drift_ms,omitempty" unit:"m/s" sheet:"Inertial guidance drift speed: [m/s]"`
+	InertialGuidanceDriftSpeed              string            `json:"inertial_guidance_drift,omitempty" bson:"inertial_guidance_drift,omitempty" sheet:"Inertial guidance drift speed:"`
+	Datalink                                string            `json:"datalink,omitempty" bson:"datalink,omitempty" sheet:"Datalink:"`
+	CanDatalinkReconnect                    string            `json:"can_datalink_reconnect,omitempty" bson:"can_datalink_reconnect,omitempty" sheet:"Can datalink reconnect:"`
+	SidelobeAttenuation                     string            `json:"sidelobe_attenuation,omitempty" bson:"sidelobe_attenuation,omitempty" sheet:"Sidelobe attenuation:"`
+	TransmitterPower                        string            `json:"transmitter_power,omitempty" bson:"transmitter_power,omitempty" sheet:"Transmitter power:"`
+	TransmitterHalfSensitivity              string            `json:"transmitter_half_sensitivity,omitempty" bson:"transmitter_half_sensitivity,omitempty" sheet:"Transmitter angle of half sensitivity:"`
+	TransmitterSidelobeSens                 string            `json:"transmitter_sidelobe_sensitivity,omitempty" bson:"transmitter_sidelobe_sensitivity,omitempty" sheet:"Transmitter sidelobe sensitivity:"`
+	ReceiverHalfSensitivity                 string            `json:"receiver_half_sensitivity,omitempty" bson:"receiver_half_sensitivity,omitempty" sheet:"Receiver angle of half sensitivity:"`
+	ReceiverSidelobeSens                    string            `json:"receiver_sidelobe_sensitivity,omitempty" bson:"receiver_sidelobe_sensitivity,omitempty" sheet:"Receiver sidelobe sensitivity:"`
+	DistanceMinValue                        string            `json:"distance_min,omitempty" bson:"distance_min,omitempty" sheet:"Distance minimum value:"`
+	DistanceMaxValue                        string            `json:"distance_max,omitempty" bson:"distance_max,omitempty" sheet:"Distance maximum value:"`
+	DistanceWidth                           string            `json:"distance_width,omitempty" bson:"distance_width,omitempty" sheet:"Distance width:"`
+	DistanceRefWidth                        string            `json:"distance_ref_width,omitempty" bson:"distance_ref_width,omitempty" sheet:"Distance refWidth:"`
+	DistanceMinSignalGate                   string            `json:"distance_min_signal_gate,omitempty" bson:"distance_min_signal_gate,omitempty" sheet:"Distance minimum signal gate:"`
+	DistanceMinValueM                       string            `json:"distance_min_m,omitempty" bson:"distance_min_m,omitempty" unit:"m" sheet:"Distance minimum value: [m]"`
+	DistanceMaxValueKm                      string            `json:"distance_max_km,omitempty" bson:"distance_max_km,omitempty" unit:"km" sheet:"Distance maximum value: [km]"`
+	DistanceWidthM                          string            `json:"distance_width_m,omitempty" bson:"distance_width_m,omitempty" unit:"m" sheet:"Distance width: [m]"`
+	DistanceRefWidthM                       string            `json:"distance_ref_width_m,omitempty" bson:"distance_ref_width_m,omitempty" unit:"m" sheet:"Distance ref width: [m]"`
+	DistanceMinSignalGateM                  string            `json:"distance_min_signal_gate_m,omitempty" bson:"distance_min_signal_gate_m,omitempty" unit:"m" sheet:"Distance minimum signal gate: [m]"`
+	DistanceGateSearchRange                 string            `json:"distance_gate_search,omitempty" bson:"distance_gate_search,omitempty" unit:"m" sheet:"Distance gate search range: [m]"`
+	DistanceGateAlphaFilter                 string            `json:"distance_gate_alpha,omitempty" bson:"distance_gate_alpha,omitempty" sheet:"Distance gate alpha filter:"`
+	DistanceGateBetaFilter                  string            `json:"distance_gate_beta,omitempty" bson:"distance_gate_beta,omitempty" sheet:"Distance gate beta filter:"`
+	DopplerSpeedMinValue                    string            `json:"doppler_speed_min,omitempty" bson:"doppler_speed_min,omitempty" unit:"m/s" sheet:"Doppler speed minimum value: [m/s]"`
+	DopplerSpeedMaxValue                    string            `json:"doppler_speed_max,omitempty" bson:"doppler_speed_max,omitempty" unit:"m/s" sheet:"Doppler speed maximum value: [m/s]"`
+	DopplerSpeedWidth                       string            `json:"doppler_speed_width,omitempty" bson:"doppler_speed_width,omitempty" unit:"m/s" sheet:"Doppler speed width: [m/s]"`
+	DopplerSpeedRefWidth                    string            `json:"doppler_speed_ref_width,omitempty" bson:"doppler_speed_ref_width,omitempty" unit:"m/s" sheet:"Doppler speed ref width: [m/s]"`
+	DopplerSpeedMinSignalGate               string            `json:"doppler_speed_min_gate,omitempty" bson:"doppler_speed_min_gate,omitempty" unit:"m/s" sheet:"Doppler speed minimum signal gate: [m/s]"`
+	DopplerSpeedGateSearch                  string            `json:"doppler_speed_gate_search,omitempty" bson:"doppler_speed_gate_search,omitempty" unit:"m/s" sheet:"Doppler speed gate search range: [m/s]"`
+	DopplerSpeedGateAlpha                   string            `json:"doppler_speed_gate_alpha,omitempty" bson:"doppler_speed_gate_alpha,omitempty" sheet:"Doppler speed gate alpha filter:"`
+	DopplerSpeedGateBeta                    string            `json:"doppler_speed_gate_beta,omitempty" bson:"doppler_speed_gate_beta,omitempty" sheet:"Doppler speed gate beta filter:"`
+	ProportionalNavMultiplier               string            `json:"proportional_nav_multiplier,omitempty" bson:"proportional_nav_multiplier,omitempty" sheet:"Proportional navigation multiplier: (affects how far ahead it attempts to lead)"`
+	BaseIndicatedAirSpeed                   string            `json:"base_air_speed,omitempty" bson:"base_air_speed,omitempty" unit:"m/s" sheet:"Base indicated air speed: [m/s]"`
+	PIDProportionalTerm                     string            `json:"pid_proportional,omitempty" bson:"pid_proportional,omitempty" sheet:"PID proportional term:"`
+	PIDIntegralTerm                         string            `json:"pid_integral,omitempty" bson:"pid_integral,omitempty" sheet:"PID integral term:"`
+	PIDIntegralTermLimit                    string            `json:"pid_integral_limit,omitempty" bson:"pid_integral_limit,omitempty" sheet:"PID integral term limit:"`
+	PIDDerivativeTerm                       string            `json:"pid_derivative,omitempty" bson:"pid_derivative,omitempty" sheet:"PID derivative term:"`
+	OrientingPhase                          string            `json:"orienting_phase,omitempty" bson:"orienting_phase,omitempty" sheet:"Orienting phase:"`
+	OrientingStartDelay                     string            `json:"orienting_start_delay,omitempty" bson:"orienting_start_delay,omitempty" unit:"s" sheet:"Orienting start delay: [s]"`
+	OrientingControlTime                    string            `json:"orienting_control_time,omitempty" bson:"orienting_control_time,omitempty" unit:"s" sheet:"Orienting control time: [s]"`
+	OrientingElevationAddition              string            `json:"orienting_elevation_addition,omitempty" bson:"orienting_elevation_addition,omitempty" unit:"m" sheet:"Orienting elevation addition: [m]"`
+	DragCoefficientMultiplier               string            `json:"drag_coefficient_multiplier,omitempty" bson:"drag_coefficient_multiplier,omitempty" sheet:"Drag coefficient multiplier (this is not the only value affecting drag, just because it's higher than another missile's doesn't mean it actually has higher drag!!):"`
+	DragCoefficientMultiplierBomb           string            `json:"drag_coefficient_multiplier_bomb,omitempty" bson:"drag_coefficient_multiplier_bomb,omitempty" sheet:"Drag coefficient multiplier (this is not the only value affecting drag, just because it's higher than another bomb's doesn't mean it actually has higher drag!!):"`
+	WingAreaMultiplier                      string            `json:"wing_area_multiplier,omitempty" bson:"wing_area_multiplier,omitempty" sheet:"Wing area multiplier:"`
+	StartSpeed                              string            `json:"start_speed,omitempty" bson:"start_speed,omitempty" unit:"m/s" sheet:"Start speed: [m/s]"`
+	MaximumSpeed                            string            `json:"maximum_speed,omitempty" bson:"maximum_speed,omitempty" unit:"m/s" sheet:"Maximum speed: [m/s]"`
+	MinimumRange                            string            `json:"minimum_range,omitempty" bson:"minimum_range,omitempty" unit:"m" sheet:"Minimum range: [m]"`
+	MinimumRangeKm                          string            `json:"minimum_range_km,omitempty" bson:"minimum_range_km,omitempty" unit:"km" sheet:"Minimum range: [km]"`
+	FlightRangeLimit                        string            `json:"flight_range_limit,omitempty" bson:"flight_range_limit,omitempty" unit:"km" sheet:"Flight range limit: [km]"`
+	MaximumGLoad                            string            `json:"maximum_g_load,omitempty" bson:"maximum_g_load,omitempty" unit:"G" sheet:"Maximum G-load: [G]"`
+	MaximumFinAngleOfAttack                 string            `json:"maximum_fin_angle_of_attack,omitempty" bson:"maximum_fin_angle_of_attack,omitempty" unit:"degrees" sheet:"Maximum fin angle of attack: [degrees]"`
+	MaximumFinLateralAcceleration           string            `json:"maximum_fin_lateral_acceleration,omitempty" bson:"maximum_fin_lateral_acceleration,omitempty" sheet:"Maximum fin lateral acceleration:"`
+	FinsLateralAcceleration                 string            `json:"fins_lateral_acceleration,omitempty" bson:"fins_lateral_acceleration,omitempty" sheet:"Fins lateral acceleration:"`
+	MaximumLateralAcceleration              string            `json:"maximum_lateral_acceleration,omitempty" bson:"maximum_lateral_acceleration,omitempty" sheet:"Maximum lateral acceleration:"`
+	MaxLateralAcceleration                  string            `json:"max_lateral_acceleration,omitempty" bson:"max_lateral_acceleration,omitempty" sheet:"Max lateral acceleration:"`
+	MaximumAOA                              string            `json:"maximum_aoa,omitempty" bson:"maximum_aoa,omitempty" unit:"degrees" sheet:"Maximum AOA: [degrees]"`
+	ThrustVectoring                         string            `json:"thrust_vectoring,omitempty" bson:"thrust_vectoring,omitempty" sheet:"Thrust vectoring:"`
+	ThrustVectoringAngle                    string            `json:"thrust_vectoring_angle,omitempty" bson:"thrust_vectoring_angle,omitempty" unit:"degrees" sheet:"Thrust vectoring angle: [degrees]"`
+	ThrustVectoringAngles                   string            `json:"thrust_vectoring_angles,omitempty" bson:"thrust_vectoring_angles,omitempty" unit:"degrees" sheet:"Thrust vectoring angles: [degrees]"`
+	MaximumLaunchAngleHorizontalVertical    string            `json:"maximum_launch_angle_horizontal_vertical,omitempty" bson:"maximum_launch_angle_horizontal_vertical,omitempty" unit:"degrees" sheet:"Maximum launch angle (horizontally / vertically): [degrees]"`
+	MaximumLaunchAngle                      string            `json:"maximum_launch_angle,omitempty" bson:"maximum_launch_angle,omitempty" unit:"degrees" sheet:"Maximum launch angle: [degrees]"`
+	MaximumAxisValues                       string            `json:"maximum_axis_values,omitempty" bson:"maximum_axis_values,omitempty" sheet:"Maximum axis values:"`
+	StatcardSpeedMach                       string            `json:"statcard_speed_mach,omitempty" bson:"statcard_speed_mach,omitempty" unit:"Mach" sheet:"Maximum statcard (useless) speed: [Mach]"`
+	StatcardSpeedMs                         string            `json:"statcard_speed_ms,omitempty" bson:"statcard_speed_ms,omitempty" unit:"m/s" sheet:"Maximum statcard (useless) speed: [m/s]"`
+	StatcardSpeedMsOrMach                   string            `json:"statcard_speed_ms_or_mach,omitempty" bson:"statcard_speed_ms_or_mach,omitempty" sheet:"Maximum statcard (useless) speed: [m/s] or [Mach]"`
+	StatcardLaunchRange                     string            `json:"statcard_launch_range,omitempty" bson:"statcard_launch_range,omitempty" unit:"km" sheet:"Maximum statcard (useless) launch range: [km]"`
+	StatcardGuaranteedRange                 string            `json:"statcard_guaranteed_range,omitempty" bson:"statcard_guaranteed_range,omitempty" unit:"km" sheet:"Statcard (useless) guaranteed range: [km]"`
+	MaximumStatcardGLoad                    string            `json:"maximum_statcard_g_load,omitempty" bson:"maximum_statcard_g_load,omitempty" unit:"G" sheet:"Maximum statcard (useless) G-load: [G]"`
+	StatcardMaxGLoad                        string            `json:"statcard_max_g_load,omitempty" bson:"statcard_max_g_load,omitempty" unit:"G" sheet:"Statcard (useless) max G-load: [G]"`
+	FlightTimeUntilGuidanceStarts           string            `json:"flight_time_until_guidance_starts,omitempty" bson:"flight_time_until_guidance_starts,omitempty" unit:"s" sheet:"Flight time until guidance starts (delay): [s]"`
+	FlightTimeWhenPullLimitX                string            `json:"flight_time_when_pull_limit_x,omitempty" bson:"flight_time_when_pull_limit_x,omitempty" unit:"s/%" sheet:"Flight time when pull limit reaches x%: [s/%]"`
+	FlightTimeWhenPullLimit100              string            `json:"flight_time_when_pull_limit_100,omitempty" bson:"flight_time_when_pull_limit_100,omitempty" unit:"s" sheet:"Flight time when pull limit reaches 100%: [s]"`
+	ETAtoImpactWhenPropMultiplier           string            `json:"eta_to_impact_when_prop_multiplier,omitempty" bson:"eta_to_impact_when_prop_multiplier,omitempty" unit:"s/%" sheet:"ETA to impact when prop multiplier reaches x%: [s/%]"`
+	Loft                                    string            `json:"loft,omitempty" bson:"loft,omitempty" sheet:"Loft:"`
+	LoftAngle                               string            `json:"loft_angle,omitempty" bson:"loft_angle,omitempty" unit:"degrees" sheet:"Loft angle: [degrees]"`
+	LoftA                                   string            `json:"loft_a,omitempty" bson:"loft_a,omitempty" sheet:"Loft angle:"`
+	TargetElevation                         string            `json:"target_elevation,omitempty" bson:"target_elevation,omitempty" unit:"degrees" sheet:"Target elevation: [degrees]"`
+	TargetE                                 string            `json:"target_e,omitempty" bson:"target_e,omitempty" sheet:"Target elevation:"`
+	MaximumTargetAngularChange              string            `json:"maximum_target_angular_change,omitempty" bson:"maximum_target_angular_change,omitempty" unit:"degrees/s" sheet:"Maximum target angular change:  [degrees/s]"`
+	HasTracerInTail                         string            `json:"has_tracer_in_tail,omitempty" bson:"has_tracer_in_tail,omitempty" sheet:"Has a tracer in its tail:"`
+	SeaSkimming                             string            `json:"sea_skimming,omitempty" bson:"sea_skimming,omitempty" sheet:"Sea skimming:"`
+	ETAtoImpactWhenSeaAltitudeReachesMetres string            `json:"eta_to_impact_when_sea_altitude_reaches_metres,omitempty" bson:"eta_to_impact_when_sea_altitude_reaches_metres,omitempty" unit:"s/m" sheet:"ETA to impact when sea skimming altitude reaches x metres: [s/m]"`
+	SkimAltitude                            string            `json:"skim_altitude,omitempty" bson:"skim_altitude,omitempty" unit:"m" sheet:"Skim altitude: [m]"`
+	AttackAltitude                          string            `json:"attack_altitude,omitempty" bson:"attack_altitude,omitempty" unit:"m" sheet:"Attack altitude: [m]"`
+	AdditionalNotes                         string            `json:"additional_notes,omitempty" bson:"additional_notes,omitempty" kind:"text" sheet:"Additional Notes:"`
+	RetiredIn                               string            `json:"retired_in,omitempty" bson:"retired_in,omitempty"`
+	Retired                                 bool              `json:"retired,omitempty" bson:"retired,omitempty"`
+	Aliases                                 []string          `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	Column                                  int               `json:"-" bson:"column,omitempty"`
+	Stats                                   Stats             `json:"stats,omitempty" bson:"stats,omitempty"`
+	Quantities                              Stats             `json:"quantities,omitempty" bson:"quantities,omitempty"`
+	Extra                                   map[string]string `json:"extra,omitempty" bson:"extra,omitempty"`
 }
@@ -0,0 +1,7 @@
+package types
+
+type WeaponRef struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
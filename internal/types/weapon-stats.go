@@ -0,0 +1,10 @@
+package types
+
+type Stats map[string]Stat
+
+type Stat struct {
+	Value *float64  `json:"value,omitempty" bson:"value,omitempty"`
+	Range []float64 `json:"range,omitempty" bson:"range,omitempty"`
+	Bool  *bool     `json:"bool,omitempty" bson:"bool,omitempty"`
+	Unit  string    `json:"unit,omitempty" bson:"unit,omitempty"`
+}
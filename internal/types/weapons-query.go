@@ -0,0 +1,37 @@
+package types
+
+const (
+	OpEq  = "="
+	OpNe  = "!="
+	OpGt  = ">"
+	OpGte = ">="
+	OpLt  = "<"
+	OpLte = "<="
+)
+
+type WeaponsQuery struct {
+	Categories     []string
+	Guidance       []string
+	Warheads       []string
+	Filters        []Filter
+	Sort           []SortField
+	Fields         []string
+	IncludeRetired bool
+	Page           Page
+}
+
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
@@ -4,19 +4,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	csvreader "github.com/erknas/wt-guided-weapons/internal/lib/csv-reader"
+	"github.com/erknas/wt-guided-weapons/internal/types"
 )
 
-func Load(fileName string) (map[string]string, error) {
+func Load(fileName string) (types.Tables, error) {
 	data, err := os.ReadFile(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", fileName, err)
 	}
 
-	urls := make(map[string]string, 31)
+	tables := make(types.Tables, 31)
 
-	if err := json.Unmarshal(data, &urls); err != nil {
+	if err := json.Unmarshal(data, &tables); err != nil {
 		return nil, fmt.Errorf("failed to decode data: %w", err)
 	}
 
-	return urls, nil
+	for category, table := range tables {
+		if table.URL == "" {
+			return nil, fmt.Errorf("missing url for %s", category)
+		}
+
+		table.URL = resolveDir(category, table.URL)
+		tables[category] = table
+	}
+
+	return tables, nil
+}
+
+func resolveDir(category, url string) string {
+	if !strings.HasPrefix(url, csvreader.FileScheme) {
+		return url
+	}
+
+	dir := csvreader.FilePath(url)
+
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return url
+	}
+
+	return csvreader.FileScheme + filepath.Join(dir, category+".csv")
 }
@@ -6,7 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/erknas/wt-guided-weapons/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +22,7 @@ func TestLoad(t *testing.T) {
 		wantErr     bool
 		errContains string
 		wantLen     int
+		want        types.Tables
 	}{
 		{
 			name: "success",
@@ -37,6 +40,88 @@ func TestLoad(t *testing.T) {
 			},
 			wantLen: 31,
 		},
+		{
+			name: "object schema",
+			prepareFile: func(t *testing.T) string {
+				filePath := filepath.Join(tmpDir, "object.json")
+				data := `{
+					"version": "http://example.com/version",
+					"aam-arh": {"url": "http://example.com/0", "title": "ARH AAM", "family": "AAM", "guidance": "ARH"}
+				}`
+				err := os.WriteFile(filePath, []byte(data), 0644)
+				require.NoError(t, err)
+				return filePath
+			},
+			wantLen: 2,
+			want: types.Tables{
+				"version": {URL: "http://example.com/version"},
+				"aam-arh": {URL: "http://example.com/0", Title: "ARH AAM", Family: "AAM", Guidance: "ARH"},
+			},
+		},
+		{
+			name: "file and directory entries",
+			prepareFile: func(t *testing.T) string {
+				dataDir := filepath.Join(tmpDir, "data")
+				err := os.MkdirAll(dataDir, 0755)
+				require.NoError(t, err)
+
+				filePath := filepath.Join(tmpDir, "files.json")
+				data := fmt.Sprintf(`{
+					"version": "file://%[1]s",
+					"aam-arh": {"url": "file://%[1]s"},
+					"aam-sarh": "file://%[1]s/sarh.csv"
+				}`, dataDir)
+				err = os.WriteFile(filePath, []byte(data), 0644)
+				require.NoError(t, err)
+				return filePath
+			},
+			wantLen: 3,
+			want: types.Tables{
+				"version":  {URL: "file://" + filepath.Join(tmpDir, "data", "version.csv")},
+				"aam-arh":  {URL: "file://" + filepath.Join(tmpDir, "data", "aam-arh.csv")},
+				"aam-sarh": {URL: "file://" + filepath.Join(tmpDir, "data", "sarh.csv")},
+			},
+		},
+		{
+			name: "schedule and priority",
+			prepareFile: func(t *testing.T) string {
+				filePath := filepath.Join(tmpDir, "schedule.json")
+				data := `{
+					"aam-arh": {"url": "http://example.com/0", "refresh": "10m", "priority": 10},
+					"sam-ir-naval": {"url": "http://example.com/1", "refresh": "24h"}
+				}`
+				err := os.WriteFile(filePath, []byte(data), 0644)
+				require.NoError(t, err)
+				return filePath
+			},
+			wantLen: 2,
+			want: types.Tables{
+				"aam-arh":      {URL: "http://example.com/0", Refresh: 10 * time.Minute, Priority: 10},
+				"sam-ir-naval": {URL: "http://example.com/1", Refresh: 24 * time.Hour},
+			},
+		},
+		{
+			name: "invalid refresh",
+			prepareFile: func(t *testing.T) string {
+				filePath := filepath.Join(tmpDir, "invalid_refresh.json")
+				err := os.WriteFile(filePath, []byte(`{"aam-arh": {"url": "http://example.com/0", "refresh": "often"}}`), 0644)
+				require.NoError(t, err)
+				return filePath
+			},
+			wantErr:     true,
+			errContains: "invalid refresh",
+		},
+		{
+			name: "missing url",
+			prepareFile: func(t *testing.T) string {
+				filePath := filepath.Join(tmpDir, "missing_url.json")
+				err := os.WriteFile(filePath, []byte(`{"aam-arh": {"title": "ARH AAM"}}`), 0644)
+				require.NoError(t, err)
+				return filePath
+			},
+			wantErr:     true,
+			errContains: "missing url for aam-arh",
+		},
 		{
 			name: "file not exists",
 			prepareFile: func(t *testing.T) string {
@@ -82,6 +167,9 @@ func TestLoad(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.Len(t, result, tt.wantLen)
+				if tt.want != nil {
+					assert.Equal(t, tt.want, result)
+				}
 			}
 		})
 	}